@@ -0,0 +1,196 @@
+// finovatex 是辅助性的命令行工具集合，目前提供 vectors 子命令用于管理
+// internal/backtest 使用的测试向量
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/finovatex/finovatex/internal/backtest"
+	"github.com/finovatex/finovatex/internal/exchange"
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatalf("执行失败: %v", err)
+	}
+}
+
+func run(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("用法: finovatex vectors <record|regen> [flags]")
+	}
+
+	switch args[0] {
+	case "vectors":
+		return runVectors(args[1:])
+	default:
+		return fmt.Errorf("未知命令: %s", args[0])
+	}
+}
+
+func runVectors(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("用法: finovatex vectors <record|regen> [flags]")
+	}
+
+	switch args[0] {
+	case "record":
+		return runVectorsRecord(args[1:])
+	case "regen":
+		return runVectorsRegen(args[1:])
+	default:
+		return fmt.Errorf("未知的vectors子命令: %s", args[0])
+	}
+}
+
+// runVectorsRecord 录制一段live NATS行情窗口为新的测试向量；录制期间同时
+// 跑一份策略实例，把它产出的信号写成 expect_signal 事件，作为未来回归的
+// 基线
+func runVectorsRecord(args []string) error {
+	fs := flag.NewFlagSet("vectors record", flag.ExitOnError)
+	natsURL := fs.String("nats-url", "nats://localhost:4222", "NATS服务器地址")
+	symbol := fs.String("symbol", "BTCUSDT", "录制的交易对")
+	strategyName := fs.String("strategy", "nr_breakout", "用于生成expect_signal基线的策略")
+	duration := fs.Duration("duration", 30*time.Second, "录制时长")
+	out := fs.String("out", "testdata/vectors/recorded.jsonl.gz", "输出的向量文件路径")
+	commitSHA := fs.String("commit", "", "写入manifest的commit SHA")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	strat, err := strategy.BuildStrategy(*strategyName, nil)
+	if err != nil {
+		return fmt.Errorf("构造策略失败: %w", err)
+	}
+	replayer := backtest.NewReplayer(strat)
+
+	conn, err := nats.Connect(*natsURL)
+	if err != nil {
+		return fmt.Errorf("连接NATS失败: %w", err)
+	}
+	defer conn.Close()
+
+	js, err := conn.JetStream()
+	if err != nil {
+		return fmt.Errorf("创建JetStream上下文失败: %w", err)
+	}
+
+	var events []backtest.Event
+
+	klineSub, err := js.Subscribe(fmt.Sprintf("market.klines.%s", *symbol), func(msg *nats.Msg) {
+		var k exchange.Kline
+		if err := json.Unmarshal(msg.Data, &k); err != nil {
+			log.Printf("解析kline消息失败: %v", err)
+			return
+		}
+		events = append(events, backtest.Event{Kind: backtest.EventKline, Symbol: *symbol, Kline: &k})
+
+		signals, err := replayer.Run(&backtest.Vector{Events: []backtest.Event{{Kind: backtest.EventKline, Symbol: *symbol, Kline: &k}}})
+		if err != nil {
+			log.Printf("回放策略失败: %v", err)
+			return
+		}
+		for i := range signals {
+			events = append(events, backtest.Event{Kind: backtest.EventExpectSignal, Symbol: *symbol, Signal: &signals[i]})
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("订阅market.klines.%s失败: %w", *symbol, err)
+	}
+	defer klineSub.Unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case <-ctx.Done():
+	case <-sigChan:
+		log.Println("收到中断信号，提前结束录制")
+	}
+
+	vector := &backtest.Vector{
+		Manifest: backtest.Manifest{
+			StrategyConfigHash: *strategyName,
+			Seed:               time.Now().UnixNano(),
+			CommitSHA:          *commitSHA,
+		},
+		Events: events,
+	}
+	if err := backtest.SaveVector(*out, vector); err != nil {
+		return fmt.Errorf("保存向量失败: %w", err)
+	}
+
+	fmt.Printf("录制完成: %d个事件已写入 %s\n", len(events), *out)
+	return nil
+}
+
+// runVectorsRegen 在策略逻辑发生有意变更后，重新用当前策略回放已有向量
+// 的输入事件，并用新产出的信号覆盖其中的expect_signal事件
+func runVectorsRegen(args []string) error {
+	fs := flag.NewFlagSet("vectors regen", flag.ExitOnError)
+	path := fs.String("vector", "", "要重新生成的向量文件路径")
+	strategyName := fs.String("strategy", "nr_breakout", "用于重新生成expect_signal的策略")
+	commitSHA := fs.String("commit", "", "写入manifest的commit SHA")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("regen操作需要指定-vector")
+	}
+
+	vector, err := backtest.LoadVector(*path)
+	if err != nil {
+		return fmt.Errorf("加载向量失败: %w", err)
+	}
+
+	strat, err := strategy.BuildStrategy(*strategyName, nil)
+	if err != nil {
+		return fmt.Errorf("构造策略失败: %w", err)
+	}
+	replayer := backtest.NewReplayer(strat)
+
+	produced, err := replayer.Run(vector)
+	if err != nil {
+		return fmt.Errorf("回放策略失败: %w", err)
+	}
+
+	var regenerated []backtest.Event
+	for _, ev := range vector.Events {
+		if ev.Kind == backtest.EventExpectSignal {
+			continue
+		}
+		regenerated = append(regenerated, ev)
+	}
+	for i := range produced {
+		regenerated = append(regenerated, backtest.Event{
+			Kind:   backtest.EventExpectSignal,
+			Symbol: produced[i].Symbol,
+			Signal: &produced[i],
+		})
+	}
+
+	vector.Events = regenerated
+	vector.Manifest.StrategyConfigHash = *strategyName
+	vector.Manifest.CommitSHA = *commitSHA
+
+	if err := backtest.SaveVector(*path, vector); err != nil {
+		return fmt.Errorf("保存向量失败: %w", err)
+	}
+
+	fmt.Printf("重新生成完成: %d个期望信号\n", len(produced))
+	return nil
+}
@@ -18,12 +18,13 @@ func main() {
 
 func run() error {
 	// 解析命令行参数
-	action := flag.String("action", "", "操作类型: up, down, version, goto, force, drop, create, info")
+	action := flag.String("action", "", "操作类型: up, down, version, goto, force, drop, create, info, status, redo")
 	steps := flag.Int("steps", 1, "down操作的步数")
-	version := flag.Uint("version", 0, "goto或force操作的目标版本")
+	version := flag.Uint("version", 0, "goto或dry-run操作的目标版本")
 	forceVer := flag.Int("force", -1, "强制设置的版本号")
 	migrationName := flag.String("name", "", "create操作的迁移文件名")
 	migrationsDir := flag.String("dir", "./migrations", "迁移文件目录")
+	dryRun := flag.Bool("dry-run", false, "仅对up/down/goto打印执行计划并回滚，不实际变更数据库")
 	flag.Parse()
 
 	if *action == "" {
@@ -49,7 +50,7 @@ func run() error {
 		config.User, config.Password, config.Host, config.Port, config.DBName, config.SSLMode)
 	
 	// 创建迁移管理器
-	mm, err := database.NewMigrationManager(dbURL, *migrationsDir)
+	mm, err := database.NewMigrationManager(db, dbURL, *migrationsDir)
 	if err != nil {
 		return fmt.Errorf("创建迁移管理器失败: %w", err)
 	}
@@ -62,12 +63,21 @@ func run() error {
 	// 执行操作
 	switch *action {
 	case "up":
+		if *dryRun {
+			return handleDryRun(mm, "up", *version)
+		}
 		return handleUp(mm)
 	case "down":
+		if *dryRun {
+			return handleDryRun(mm, "down", *version)
+		}
 		return handleDown(mm, *steps)
 	case "version":
 		return handleVersion(mm)
 	case "goto":
+		if *dryRun {
+			return handleDryRun(mm, "goto", *version)
+		}
 		return handleGoto(mm, *version)
 	case "force":
 		return handleForce(mm, *forceVer)
@@ -77,6 +87,10 @@ func run() error {
 		return handleCreate(*migrationsDir, *migrationName)
 	case "info":
 		return handleInfo(mm)
+	case "status":
+		return handleStatus(mm)
+	case "redo":
+		return handleRedo(mm)
 	default:
 		return fmt.Errorf("未知操作: %s", *action)
 	}
@@ -179,6 +193,58 @@ func handleInfo(mm *database.MigrationManager) error {
 	return nil
 }
 
+// handleStatus 处理status操作：列出每个迁移文件的applied/pending状态
+func handleStatus(mm *database.MigrationManager) error {
+	statuses, err := mm.Status()
+	if err != nil {
+		return fmt.Errorf("获取迁移状态失败: %w", err)
+	}
+
+	for _, s := range statuses {
+		state := "pending"
+		if s.Applied {
+			state = "applied"
+		}
+		fmt.Printf("%06d_%s: %s (checksum=%s)\n", s.Version, s.Name, state, s.Checksum)
+	}
+	return nil
+}
+
+// handleRedo 处理redo操作：在事务内回滚并重新应用最新一次迁移
+func handleRedo(mm *database.MigrationManager) error {
+	if err := mm.Redo(); err != nil {
+		return fmt.Errorf("redo失败: %w", err)
+	}
+	fmt.Println("操作完成")
+	return nil
+}
+
+// handleDryRun 处理up/down/goto的-dry-run模式：打印执行计划并回滚
+func handleDryRun(mm *database.MigrationManager, action string, version uint) error {
+	var (
+		plans []database.DryRunPlan
+		err   error
+	)
+
+	switch action {
+	case "up", "goto":
+		plans, err = mm.DryRunUp(version)
+	case "down":
+		plans, err = mm.DryRunDown(version)
+	}
+	if err != nil {
+		return fmt.Errorf("dry-run失败: %w", err)
+	}
+
+	for _, p := range plans {
+		fmt.Printf("-- %s\n", p.Statement)
+		for _, line := range p.Plan {
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
+
 // createMigrationFiles 创建新的迁移文件
 func createMigrationFiles(migrationsDir, name string) error {
 	// 确保迁移目录存在
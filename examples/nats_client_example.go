@@ -11,6 +11,9 @@ import (
 	"time"
 
 	"github.com/nats-io/nats.go"
+
+	"github.com/finovatex/finovatex/internal/notifier"
+	"github.com/finovatex/finovatex/internal/strategy"
 )
 
 // MarketData 市场数据结构
@@ -334,17 +337,57 @@ func generateRandomAction() string {
 	return "SELL"
 }
 
+// signalNotifier 懒加载的通知器，配置缺失时保持为nil，此时仅打印日志
+var signalNotifier notifier.Notifier
+
+func loadSignalNotifier() notifier.Notifier {
+	cfgPath := os.Getenv("FINOVATEX_NOTIFIER_CONFIG")
+	if cfgPath == "" {
+		return nil
+	}
+
+	cfg, err := notifier.LoadConfigFromFile(cfgPath)
+	if err != nil {
+		log.Printf("加载通知配置失败，告警将仅打印到stdout: %v", err)
+		return nil
+	}
+
+	m, err := notifier.BuildMultiNotifier(cfg)
+	if err != nil {
+		log.Printf("构建通知器失败，告警将仅打印到stdout: %v", err)
+		return nil
+	}
+	return m
+}
+
 func processTradingSignal(signal TradingSignal) {
 	// 模拟信号处理逻辑
 	log.Printf("  → Processing %s signal for %s...", signal.Action, signal.Symbol)
-	
+
 	// 模拟风险检查
 	if signal.Quantity > 1.0 {
 		log.Printf("  ⚠️  Risk check: Large quantity detected (%.4f)", signal.Quantity)
 	}
-	
+
 	// 模拟执行延迟
 	time.Sleep(50 * time.Millisecond)
-	
+
+	if signalNotifier == nil {
+		signalNotifier = loadSignalNotifier()
+	}
+	if signalNotifier != nil {
+		converted := strategy.TradingSignal{
+			StrategyID: signal.StrategyID,
+			Symbol:     signal.Symbol,
+			Action:     strategy.SignalAction(signal.Action),
+			Price:      signal.Price,
+			Quantity:   signal.Quantity,
+			Timestamp:  signal.Timestamp,
+		}
+		if err := signalNotifier.NotifySignal(converted); err != nil {
+			log.Printf("  ⚠️  推送信号到外部通知渠道失败: %v", err)
+		}
+	}
+
 	log.Printf("  ✅ Signal processed successfully")
 }
\ No newline at end of file
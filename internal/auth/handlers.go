@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// loginRequest 是 POST /auth/login 的请求体
+type loginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// refreshRequest 是 POST /auth/refresh 和 POST /auth/logout 的请求体
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RegisterRoutes 在给定的路由组下挂载 /auth/login、/auth/refresh、/auth/logout
+func RegisterRoutes(rg *gin.RouterGroup, svc *Service) {
+	rg.POST("/auth/login", func(c *gin.Context) { handleLogin(c, svc) })
+	rg.POST("/auth/refresh", func(c *gin.Context) { handleRefresh(c, svc) })
+	rg.POST("/auth/logout", func(c *gin.Context) { handleLogout(c, svc) })
+}
+
+func handleLogin(c *gin.Context, svc *Service) {
+	var req loginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pair, err := svc.Login(c.Request.Context(), req.Username, req.Password, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse(pair))
+}
+
+func handleRefresh(c *gin.Context, svc *Service) {
+	var req refreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pair, err := svc.Refresh(c.Request.Context(), req.RefreshToken)
+	if errors.Is(err, ErrTokenReused) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "刷新令牌已失效，请重新登录"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse(pair))
+}
+
+func handleLogout(c *gin.Context, svc *Service) {
+	var req refreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := svc.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "已登出"})
+}
+
+func tokenResponse(pair *TokenPair) gin.H {
+	return gin.H{
+		"access_token":  pair.AccessToken,
+		"refresh_token": pair.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    pair.ExpiresIn,
+	}
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims 是访问令牌携带的自定义声明
+type Claims struct {
+	UserID string   `json:"uid"`
+	Scopes []string `json:"scopes"`
+	jwt.RegisteredClaims
+}
+
+// TokenIssuer 签发并校验JWT访问令牌
+type TokenIssuer struct {
+	signingKey []byte
+	issuer     string
+}
+
+// NewTokenIssuer 创建令牌签发器，signingKey通常来自环境变量
+func NewTokenIssuer(signingKey []byte, issuer string) *TokenIssuer {
+	return &TokenIssuer{signingKey: signingKey, issuer: issuer}
+}
+
+// IssueAccessToken 签发一个15分钟有效期的访问令牌
+func (t *TokenIssuer) IssueAccessToken(userID string, scopes []string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID: userID,
+		Scopes: scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    t.issuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(t.signingKey)
+	if err != nil {
+		return "", fmt.Errorf("签发访问令牌失败: %w", err)
+	}
+	return signed, nil
+}
+
+// ParseAccessToken 校验并解析访问令牌
+func (t *TokenIssuer) ParseAccessToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("非预期的签名算法: %v", token.Header["alg"])
+		}
+		return t.signingKey, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("解析访问令牌失败: %w", err)
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("访问令牌无效")
+	}
+	return claims, nil
+}
+
+// HasScope 判断令牌声明中是否包含指定scope，admin scope隐含拥有一切权限
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope || s == string(ScopeAdmin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package auth
+
+import "testing"
+
+func TestIssueAndParseAccessToken(t *testing.T) {
+	issuer := NewTokenIssuer([]byte("test-secret"), "finovatex-test")
+
+	token, err := issuer.IssueAccessToken("user-1", []string{string(ScopeMarketRead)})
+	if err != nil {
+		t.Fatalf("签发令牌失败: %v", err)
+	}
+
+	claims, err := issuer.ParseAccessToken(token)
+	if err != nil {
+		t.Fatalf("解析令牌失败: %v", err)
+	}
+
+	if claims.UserID != "user-1" {
+		t.Fatalf("期望UserID为user-1，得到 %s", claims.UserID)
+	}
+	if !claims.HasScope(string(ScopeMarketRead)) {
+		t.Fatalf("claims应包含market:read scope")
+	}
+	if claims.HasScope(string(ScopeOrdersWrite)) {
+		t.Fatalf("claims不应包含orders:write scope")
+	}
+}
+
+func TestParseAccessTokenRejectsWrongSecret(t *testing.T) {
+	issuer := NewTokenIssuer([]byte("secret-a"), "finovatex-test")
+	token, err := issuer.IssueAccessToken("user-1", nil)
+	if err != nil {
+		t.Fatalf("签发令牌失败: %v", err)
+	}
+
+	other := NewTokenIssuer([]byte("secret-b"), "finovatex-test")
+	if _, err := other.ParseAccessToken(token); err == nil {
+		t.Fatalf("使用错误密钥解析应当失败")
+	}
+}
+
+func TestAdminScopeImpliesAllScopes(t *testing.T) {
+	claims := &Claims{Scopes: []string{string(ScopeAdmin)}}
+	if !claims.HasScope(string(ScopeOrdersWrite)) {
+		t.Fatalf("admin scope应隐含其他所有scope")
+	}
+}
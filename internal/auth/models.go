@@ -0,0 +1,42 @@
+// Package auth 提供OAuth2密码模式 + JWT的鉴权能力：签发短期访问令牌、
+// 轮换刷新令牌，并对Gin路由强制执行scope校验
+package auth
+
+import "time"
+
+// Scope 是JWT访问令牌携带的权限声明
+type Scope string
+
+const (
+	ScopeMarketRead  Scope = "market:read"
+	ScopeOrdersWrite Scope = "orders:write"
+	ScopeAdmin       Scope = "admin"
+)
+
+// User 是可登录的账号
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	Scopes       []string
+	LastLoginAt  *time.Time
+	LastLoginIP  string
+}
+
+// RefreshToken 是持久化的刷新令牌记录
+type RefreshToken struct {
+	ID        string
+	FamilyID  string
+	UserID    string
+	TokenHash string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+const (
+	// AccessTokenTTL 访问令牌有效期
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL 刷新令牌有效期
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
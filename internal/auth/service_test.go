@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/finovatex/finovatex/internal/database"
+)
+
+// setupServiceTest 连接真实数据库并建好auth相关表，没有可用数据库时跳过；
+// Service.Refresh依赖Store对refresh_tokens表做真实的UPDATE/INSERT，没法
+// 脱离数据库纯做mock
+func setupServiceTest(t *testing.T) (*Service, *Store) {
+	t.Helper()
+
+	config := database.LoadConfigFromEnv()
+	db, err := database.Connect(config)
+	if err != nil {
+		t.Skipf("没有可用的数据库连接，跳过: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	schema, err := os.ReadFile("../../migrations/000003_auth_tables.up.sql")
+	if err != nil {
+		t.Fatalf("读取auth表结构失败: %v", err)
+	}
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatalf("创建auth表失败: %v", err)
+	}
+
+	store := NewStore(db)
+	issuer := NewTokenIssuer([]byte("test-secret"), "finovatex-test")
+	return NewService(store, issuer), store
+}
+
+func createTestUser(t *testing.T, store *Store, password string) (userID, username string) {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("生成密码哈希失败: %v", err)
+	}
+
+	username = "svc-test-" + uuid.NewString()
+	err = store.db.QueryRow(
+		`INSERT INTO users (username, password_hash, scopes) VALUES ($1, $2, $3) RETURNING id`,
+		username, string(hash), []string{string(ScopeMarketRead)},
+	).Scan(&userID)
+	if err != nil {
+		t.Fatalf("创建测试用户失败: %v", err)
+	}
+
+	t.Cleanup(func() {
+		store.db.Exec(`DELETE FROM users WHERE id = $1`, userID)
+	})
+
+	return userID, username
+}
+
+// TestRefreshRotatesTokenAndLinksReplacedBy 验证Refresh轮换后，旧令牌的
+// replaced_by确实指向新令牌在数据库里的真实ID，而不是一个从未写入过的UUID
+func TestRefreshRotatesTokenAndLinksReplacedBy(t *testing.T) {
+	service, store := setupServiceTest(t)
+	ctx := context.Background()
+
+	const password = "correct horse battery staple"
+	_, username := createTestUser(t, store, password)
+
+	pair, err := service.Login(ctx, username, password, "127.0.0.1")
+	if err != nil {
+		t.Fatalf("登录失败: %v", err)
+	}
+
+	oldRT, err := store.GetRefreshTokenByHash(ctx, hashToken(pair.RefreshToken))
+	if err != nil {
+		t.Fatalf("查询旧刷新令牌失败: %v", err)
+	}
+
+	newPair, err := service.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("刷新失败: %v", err)
+	}
+
+	newRT, err := store.GetRefreshTokenByHash(ctx, hashToken(newPair.RefreshToken))
+	if err != nil {
+		t.Fatalf("查询新刷新令牌失败: %v", err)
+	}
+
+	var replacedBy *string
+	err = store.db.QueryRowContext(ctx,
+		`SELECT replaced_by FROM refresh_tokens WHERE id = $1`, oldRT.ID,
+	).Scan(&replacedBy)
+	if err != nil {
+		t.Fatalf("查询replaced_by失败: %v", err)
+	}
+
+	if replacedBy == nil {
+		t.Fatalf("期望replaced_by非空")
+	}
+	if *replacedBy != newRT.ID {
+		t.Fatalf("replaced_by应指向真正写入的新令牌ID %s，得到 %s", newRT.ID, *replacedBy)
+	}
+}
+
+// TestRefreshReusedTokenRevokesFamily 验证已被轮换过的刷新令牌再次被使用
+// 时，会返回ErrTokenReused并撤销整个令牌家族
+func TestRefreshReusedTokenRevokesFamily(t *testing.T) {
+	service, store := setupServiceTest(t)
+	ctx := context.Background()
+
+	const password = "correct horse battery staple"
+	_, username := createTestUser(t, store, password)
+
+	pair, err := service.Login(ctx, username, password, "127.0.0.1")
+	if err != nil {
+		t.Fatalf("登录失败: %v", err)
+	}
+
+	oldRT, err := store.GetRefreshTokenByHash(ctx, hashToken(pair.RefreshToken))
+	if err != nil {
+		t.Fatalf("查询旧刷新令牌失败: %v", err)
+	}
+
+	if _, err := service.Refresh(ctx, pair.RefreshToken); err != nil {
+		t.Fatalf("第一次刷新失败: %v", err)
+	}
+
+	// 旧令牌已被撤销，再次使用应触发重用检测
+	if _, err := service.Refresh(ctx, pair.RefreshToken); err == nil {
+		t.Fatalf("期望重用已撤销的刷新令牌返回错误")
+	}
+
+	var revokedCount int
+	err = store.db.QueryRowContext(ctx,
+		`SELECT count(*) FROM refresh_tokens WHERE family_id = $1 AND revoked_at IS NULL`, oldRT.FamilyID,
+	).Scan(&revokedCount)
+	if err != nil {
+		t.Fatalf("查询家族撤销状态失败: %v", err)
+	}
+	if revokedCount != 0 {
+		t.Fatalf("期望令牌家族全部被撤销，仍有%d个未撤销", revokedCount)
+	}
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrNotFound 表示查询的用户/令牌不存在
+var ErrNotFound = errors.New("记录不存在")
+
+// ErrTokenReused 表示一个已被轮换过的刷新令牌被再次使用，提示可能的令牌泄露
+var ErrTokenReused = errors.New("刷新令牌已被使用过，疑似令牌被盗用")
+
+// Store 封装鉴权相关表的数据库访问
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore 创建鉴权存储
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// GetUserByUsername 按用户名查找用户
+func (s *Store) GetUserByUsername(ctx context.Context, username string) (*User, error) {
+	var u User
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, username, password_hash, scopes FROM users WHERE username = $1`, username,
+	).Scan(&u.ID, &u.Username, &u.PasswordHash, pq.Array(&u.Scopes))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("查询用户失败: %w", err)
+	}
+	return &u, nil
+}
+
+// getUserByID 按ID查找用户，供刷新令牌流程重新签发访问令牌时使用
+func (s *Store) getUserByID(ctx context.Context, id string) (*User, error) {
+	var u User
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, username, password_hash, scopes FROM users WHERE id = $1`, id,
+	).Scan(&u.ID, &u.Username, &u.PasswordHash, pq.Array(&u.Scopes))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("查询用户失败: %w", err)
+	}
+	return &u, nil
+}
+
+// UpdateLastLoginAsync 异步更新用户的最近登录时间/IP，不阻塞登录响应
+func (s *Store) UpdateLastLoginAsync(userID, ip string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := s.db.ExecContext(ctx,
+			`UPDATE users SET last_login_at = now(), last_login_ip = $2 WHERE id = $1`, userID, ip)
+		if err != nil {
+			fmt.Printf("异步更新last_login失败: %v\n", err)
+		}
+	}()
+}
+
+// SaveRefreshToken 持久化一个新签发的刷新令牌
+func (s *Store) SaveRefreshToken(ctx context.Context, rt RefreshToken) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO refresh_tokens (id, family_id, user_id, token_hash, issued_at, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		rt.ID, rt.FamilyID, rt.UserID, rt.TokenHash, rt.IssuedAt, rt.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("保存刷新令牌失败: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshTokenByHash 按哈希查找刷新令牌
+func (s *Store) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	var rt RefreshToken
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, family_id, user_id, token_hash, issued_at, expires_at, revoked_at
+		 FROM refresh_tokens WHERE token_hash = $1`, tokenHash,
+	).Scan(&rt.ID, &rt.FamilyID, &rt.UserID, &rt.TokenHash, &rt.IssuedAt, &rt.ExpiresAt, &rt.RevokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("查询刷新令牌失败: %w", err)
+	}
+	return &rt, nil
+}
+
+// RevokeToken 标记单个刷新令牌已撤销，并记录其被替换成的新令牌ID
+func (s *Store) RevokeToken(ctx context.Context, tokenID, replacedBy string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = now(), replaced_by = $2 WHERE id = $1`, tokenID, replacedBy)
+	if err != nil {
+		return fmt.Errorf("撤销刷新令牌失败: %w", err)
+	}
+	return nil
+}
+
+// RevokeFamily 撤销一个令牌家族中的所有刷新令牌，用于令牌重用检测到盗用时的防御
+func (s *Store) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = $1 AND revoked_at IS NULL`, familyID)
+	if err != nil {
+		return fmt.Errorf("撤销令牌家族失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package backtest
+
+import (
+	"fmt"
+
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// Divergence 描述回放产出的信号与向量中期望信号的首个不一致之处
+type Divergence struct {
+	Index    int
+	Expected *strategy.TradingSignal
+	Actual   *strategy.TradingSignal
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("第%d个信号不一致: 期望=%+v 实际=%+v", d.Index, d.Expected, d.Actual)
+}
+
+// Replayer 在不依赖NATS的情况下，把一个向量的K线/成交事件顺序喂给策略，
+// 收集产出的信号供一致性校验使用
+type Replayer struct {
+	strat      strategy.Strategy
+	indicators map[string]*strategy.IndicatorSet
+	indCfg     strategy.IndicatorConfig
+}
+
+// NewReplayer 创建一个驱动给定策略的回放器
+func NewReplayer(strat strategy.Strategy) *Replayer {
+	return &Replayer{
+		strat:      strat,
+		indicators: make(map[string]*strategy.IndicatorSet),
+		indCfg:     strategy.DefaultIndicatorConfig(),
+	}
+}
+
+func (r *Replayer) indicatorsFor(symbol string) *strategy.IndicatorSet {
+	ind, ok := r.indicators[symbol]
+	if !ok {
+		ind = strategy.NewIndicatorSet(r.indCfg)
+		r.indicators[symbol] = ind
+	}
+	return ind
+}
+
+// Run 依次回放向量中的kline/trade事件，返回策略产出的全部信号，顺序与
+// 产生时一致
+func (r *Replayer) Run(v *Vector) ([]strategy.TradingSignal, error) {
+	var produced []strategy.TradingSignal
+
+	for _, ev := range v.Events {
+		switch ev.Kind {
+		case EventKline:
+			if ev.Kline == nil {
+				return nil, fmt.Errorf("kline事件缺少Kline字段")
+			}
+			signals, err := r.strat.OnKline(ev.Symbol, *ev.Kline, r.indicatorsFor(ev.Symbol))
+			if err != nil {
+				return nil, fmt.Errorf("回放kline事件失败: %w", err)
+			}
+			produced = append(produced, signals...)
+
+		case EventTrade:
+			if ev.Trade == nil {
+				return nil, fmt.Errorf("trade事件缺少Trade字段")
+			}
+			signals, err := r.strat.OnTrade(ev.Symbol, *ev.Trade)
+			if err != nil {
+				return nil, fmt.Errorf("回放trade事件失败: %w", err)
+			}
+			produced = append(produced, signals...)
+
+		case EventExpectSignal:
+			// expect_signal 行不驱动策略，仅用于 Diff 比对
+
+		default:
+			return nil, fmt.Errorf("未知的事件类型: %s", ev.Kind)
+		}
+	}
+
+	return produced, nil
+}
+
+// ExpectedSignals 从向量中抽取所有 expect_signal 事件，保持原始顺序
+func ExpectedSignals(v *Vector) []strategy.TradingSignal {
+	var expected []strategy.TradingSignal
+	for _, ev := range v.Events {
+		if ev.Kind == EventExpectSignal && ev.Signal != nil {
+			expected = append(expected, *ev.Signal)
+		}
+	}
+	return expected
+}
+
+// Diff 比对实际产出的信号与期望信号，返回首个不一致的信息；信号数量
+// 或任意字段不匹配都会在第一处分歧停止，而不是报告所有差异
+func Diff(expected, actual []strategy.TradingSignal) *Divergence {
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		var exp, act *strategy.TradingSignal
+		if i < len(expected) {
+			exp = &expected[i]
+		}
+		if i < len(actual) {
+			act = &actual[i]
+		}
+		if exp == nil || act == nil || !signalsEqual(*exp, *act) {
+			return &Divergence{Index: i, Expected: exp, Actual: act}
+		}
+	}
+	return nil
+}
+
+func signalsEqual(a, b strategy.TradingSignal) bool {
+	return a.StrategyID == b.StrategyID &&
+		a.Symbol == b.Symbol &&
+		a.Action == b.Action &&
+		a.Price == b.Price &&
+		a.Quantity == b.Quantity &&
+		a.BarCloseTime.Equal(b.BarCloseTime)
+}
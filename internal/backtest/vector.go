@@ -0,0 +1,134 @@
+// Package backtest 提供基于录制的行情/信号测试向量的确定性回放与一致性校验
+package backtest
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/finovatex/finovatex/internal/exchange"
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// EventKind 标识向量文件中一行记录的类型
+type EventKind string
+
+const (
+	EventKline        EventKind = "kline"
+	EventTrade        EventKind = "trade"
+	EventExpectSignal EventKind = "expect_signal"
+)
+
+// Event 是向量JSONL文件中的单行记录
+type Event struct {
+	Kind   EventKind               `json:"kind"`
+	Symbol string                  `json:"symbol"`
+	Kline  *exchange.Kline         `json:"kline,omitempty"`
+	Trade  *exchange.MarketData    `json:"trade,omitempty"`
+	Signal *strategy.TradingSignal `json:"signal,omitempty"`
+}
+
+// Manifest 描述一个向量的可复现性元数据
+type Manifest struct {
+	StrategyConfigHash string `json:"strategy_config_hash"`
+	Seed               int64  `json:"seed"`
+	CommitSHA          string `json:"commit_sha"`
+}
+
+// Vector 是加载到内存中的一个完整测试向量
+type Vector struct {
+	Manifest Manifest
+	Events   []Event
+}
+
+// manifestFileName 返回向量文件对应的manifest文件名（同目录、.manifest.json后缀）
+func manifestFileName(vectorPath string) string {
+	return vectorPath + ".manifest.json"
+}
+
+// LoadVector 从gzip压缩的JSONL文件及其同名manifest加载一个测试向量
+func LoadVector(path string) (*Vector, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开向量文件失败: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("解压向量文件失败: %w", err)
+	}
+	defer gz.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, fmt.Errorf("解析向量事件失败: %w", err)
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取向量文件失败: %w", err)
+	}
+
+	manifest, err := loadManifest(manifestFileName(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Vector{Manifest: *manifest, Events: events}, nil
+}
+
+func loadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取manifest失败: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("解析manifest失败: %w", err)
+	}
+	return &m, nil
+}
+
+// SaveVector 把一个测试向量写入gzip压缩的JSONL文件及其manifest，供
+// `finovatex vectors record`/`regen` 使用
+func SaveVector(path string, v *Vector) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建向量文件失败: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+
+	enc := json.NewEncoder(gz)
+	for _, ev := range v.Events {
+		if err := enc.Encode(ev); err != nil {
+			return fmt.Errorf("写入向量事件失败: %w", err)
+		}
+	}
+	if err := gz.Flush(); err != nil {
+		return fmt.Errorf("刷新向量文件失败: %w", err)
+	}
+
+	manifestData, err := json.MarshalIndent(v.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化manifest失败: %w", err)
+	}
+	if err := os.WriteFile(manifestFileName(path), manifestData, 0600); err != nil {
+		return fmt.Errorf("写入manifest失败: %w", err)
+	}
+
+	return nil
+}
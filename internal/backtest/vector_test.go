@@ -0,0 +1,67 @@
+package backtest
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/finovatex/finovatex/internal/exchange"
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+func TestSaveAndLoadVectorRoundTrip(t *testing.T) {
+	closeTime := time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC)
+	vector := &Vector{
+		Manifest: Manifest{StrategyConfigHash: "abc123", Seed: 42, CommitSHA: "deadbeef"},
+		Events: []Event{
+			{Kind: EventKline, Symbol: "BTCUSDT", Kline: &exchange.Kline{
+				Symbol: "BTCUSDT", CloseTime: closeTime, Close: 100, High: 110, Low: 90, Closed: true,
+			}},
+			{Kind: EventExpectSignal, Symbol: "BTCUSDT", Signal: &strategy.TradingSignal{
+				StrategyID: "nr_breakout", Symbol: "BTCUSDT", Action: strategy.SignalBuy,
+				Price: 100, BarCloseTime: closeTime,
+			}},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "vector.jsonl.gz")
+	if err := SaveVector(path, vector); err != nil {
+		t.Fatalf("保存向量失败: %v", err)
+	}
+
+	loaded, err := LoadVector(path)
+	if err != nil {
+		t.Fatalf("加载向量失败: %v", err)
+	}
+
+	if loaded.Manifest != vector.Manifest {
+		t.Fatalf("manifest不一致: 期望=%+v 实际=%+v", vector.Manifest, loaded.Manifest)
+	}
+	if len(loaded.Events) != len(vector.Events) {
+		t.Fatalf("事件数量不一致: 期望=%d 实际=%d", len(vector.Events), len(loaded.Events))
+	}
+}
+
+func TestDiffReportsFirstDivergence(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := []strategy.TradingSignal{
+		{StrategyID: "nr_breakout", Symbol: "BTCUSDT", Action: strategy.SignalBuy, Price: 100, BarCloseTime: t0},
+		{StrategyID: "nr_breakout", Symbol: "BTCUSDT", Action: strategy.SignalSell, Price: 105, BarCloseTime: t0.Add(time.Minute)},
+	}
+	actual := []strategy.TradingSignal{
+		{StrategyID: "nr_breakout", Symbol: "BTCUSDT", Action: strategy.SignalBuy, Price: 100, BarCloseTime: t0},
+		{StrategyID: "nr_breakout", Symbol: "BTCUSDT", Action: strategy.SignalSell, Price: 999, BarCloseTime: t0.Add(time.Minute)},
+	}
+
+	d := Diff(expected, actual)
+	if d == nil {
+		t.Fatalf("期望检测到分歧")
+	}
+	if d.Index != 1 {
+		t.Fatalf("期望分歧出现在索引1，实际为%d", d.Index)
+	}
+
+	if diff := Diff(expected, expected); diff != nil {
+		t.Fatalf("相同的信号序列不应产生分歧: %+v", diff)
+	}
+}
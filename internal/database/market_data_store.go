@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/finovatex/finovatex/internal/exchange"
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// MarketDataStore 把行情/信号写入TimescaleDB超表，并提供历史K线查询
+type MarketDataStore struct {
+	db *sql.DB
+}
+
+// NewMarketDataStore 创建市场数据存储
+func NewMarketDataStore(db *sql.DB) *MarketDataStore {
+	return &MarketDataStore{db: db}
+}
+
+// InsertTick 写入一条逐笔行情到 ticks 超表
+func (s *MarketDataStore) InsertTick(ctx context.Context, tick exchange.MarketData) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO ticks (time, symbol, price, volume, type) VALUES ($1, $2, $3, $4, $5)`,
+		tick.Timestamp, tick.Symbol, tick.Price, tick.Volume, tick.Type,
+	)
+	if err != nil {
+		return fmt.Errorf("写入ticks失败: %w", err)
+	}
+	return nil
+}
+
+// InsertKline1m 写入一根1分钟K线到 klines_1m 超表
+func (s *MarketDataStore) InsertKline1m(ctx context.Context, k exchange.Kline) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO klines_1m (time, symbol, open, high, low, close, volume) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		k.CloseTime, k.Symbol, k.Open, k.High, k.Low, k.Close, k.Volume,
+	)
+	if err != nil {
+		return fmt.Errorf("写入klines_1m失败: %w", err)
+	}
+	return nil
+}
+
+// InsertSignal 写入一条交易信号到 signals 超表
+func (s *MarketDataStore) InsertSignal(ctx context.Context, sig strategy.TradingSignal) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO signals (time, strategy_id, symbol, action, price, quantity, bar_close_time)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		sig.Timestamp, sig.StrategyID, sig.Symbol, sig.Action, sig.Price, sig.Quantity, sig.BarCloseTime,
+	)
+	if err != nil {
+		return fmt.Errorf("写入signals失败: %w", err)
+	}
+	return nil
+}
+
+// klineTableForInterval 把用户请求的周期映射到对应的表/连续聚合视图
+func klineTableForInterval(interval string) (string, error) {
+	switch interval {
+	case "1m":
+		return "klines_1m", nil
+	case "5m":
+		return "klines_5m", nil
+	case "1h":
+		return "klines_1h", nil
+	case "1d":
+		return "klines_1d", nil
+	default:
+		return "", fmt.Errorf("不支持的K线周期: %s", interval)
+	}
+}
+
+// QueryKlines 查询某个交易对在[from, to]范围内、指定周期的历史K线，
+// 供回测与前端图表拉取历史数据使用
+func (s *MarketDataStore) QueryKlines(ctx context.Context, symbol, interval string, from, to time.Time) ([]exchange.Kline, error) {
+	table, err := klineTableForInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT time, open, high, low, close, volume FROM %s
+		 WHERE symbol = $1 AND time >= $2 AND time <= $3 ORDER BY time ASC`, table)
+
+	rows, err := s.db.QueryContext(ctx, query, symbol, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("查询K线失败: %w", err)
+	}
+	defer rows.Close()
+
+	var klines []exchange.Kline
+	for rows.Next() {
+		var k exchange.Kline
+		if err := rows.Scan(&k.CloseTime, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume); err != nil {
+			return nil, fmt.Errorf("扫描K线行失败: %w", err)
+		}
+		k.Symbol = symbol
+		k.Interval = interval
+		k.Closed = true
+		klines = append(klines, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历K线结果失败: %w", err)
+	}
+
+	return klines, nil
+}
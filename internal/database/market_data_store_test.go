@@ -0,0 +1,26 @@
+package database
+
+import "testing"
+
+func TestKlineTableForInterval(t *testing.T) {
+	cases := map[string]string{
+		"1m": "klines_1m",
+		"5m": "klines_5m",
+		"1h": "klines_1h",
+		"1d": "klines_1d",
+	}
+
+	for interval, want := range cases {
+		got, err := klineTableForInterval(interval)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", interval, err)
+		}
+		if got != want {
+			t.Fatalf("interval %s: want table %s, got %s", interval, want, got)
+		}
+	}
+
+	if _, err := klineTableForInterval("3m"); err == nil {
+		t.Fatalf("不支持的周期应返回错误")
+	}
+}
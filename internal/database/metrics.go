@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	timescaleChunksTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "finovatex_timescaledb_chunks_total",
+		Help: "TimescaleDB超表的chunk总数",
+	}, []string{"hypertable"})
+
+	timescaleChunksCompressed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "finovatex_timescaledb_chunks_compressed",
+		Help: "TimescaleDB超表已压缩的chunk数量",
+	}, []string{"hypertable"})
+
+	timescaleCompressionRatio = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "finovatex_timescaledb_compression_ratio",
+		Help: "TimescaleDB超表压缩后/压缩前的字节数比例",
+	}, []string{"hypertable"})
+)
+
+func init() {
+	prometheus.MustRegister(timescaleChunksTotal, timescaleChunksCompressed, timescaleCompressionRatio)
+}
+
+// StartTimescaleStatsExporter 周期性拉取TimescaleDB超表统计信息并更新Prometheus指标，
+// 直到ctx被取消
+func StartTimescaleStatsExporter(ctx context.Context, db *sql.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				stats, err := GetTimescaleStats(ctx, db)
+				if err != nil {
+					log.Printf("拉取TimescaleDB统计信息失败: %v", err)
+					continue
+				}
+				for _, s := range stats {
+					timescaleChunksTotal.WithLabelValues(s.Hypertable).Set(float64(s.TotalChunks))
+					timescaleChunksCompressed.WithLabelValues(s.Hypertable).Set(float64(s.CompressedChunks))
+					timescaleCompressionRatio.WithLabelValues(s.Hypertable).Set(s.CompressionRatio)
+				}
+			}
+		}
+	}()
+}
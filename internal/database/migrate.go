@@ -20,8 +20,9 @@ type MigrationManager struct {
 	migrationsDir string
 }
 
-// NewMigrationManager 创建新的迁移管理器
-func NewMigrationManager(dbURL, migrationsDir string) (*MigrationManager, error) {
+// NewMigrationManager 创建新的迁移管理器；db用于status/redo/dry-run等
+// 需要直接访问数据库连接的操作
+func NewMigrationManager(db *sql.DB, dbURL, migrationsDir string) (*MigrationManager, error) {
 	// 获取绝对路径
 	absPath, err := filepath.Abs(migrationsDir)
 	if err != nil {
@@ -52,7 +53,7 @@ func NewMigrationManager(dbURL, migrationsDir string) (*MigrationManager, error)
 		return nil, fmt.Errorf("创建迁移实例失败: %w", err)
 	}
 
-	return &MigrationManager{migrate: m}, nil
+	return &MigrationManager{db: db, migrate: m, migrationsDir: absPath}, nil
 }
 
 // Up 应用所有待执行的迁移
@@ -0,0 +1,229 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MigrationStatus 描述单个迁移文件相对于当前已应用版本的状态
+type MigrationStatus struct {
+	Version  uint   `json:"version"`
+	Name     string `json:"name"`
+	Applied  bool   `json:"applied"`
+	Checksum string `json:"checksum"`
+}
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(.+)\.up\.sql$`)
+
+// Status 列出迁移目录下的每个迁移文件及其相对于当前版本的applied/pending
+// 状态和内容的sha256校验和
+func (mm *MigrationManager) Status() ([]MigrationStatus, error) {
+	currentVersion, _, err := mm.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(mm.migrationsDir, "*.up.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("扫描迁移文件失败: %w", err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(files))
+	for _, f := range files {
+		match := migrationFileRe.FindStringSubmatch(filepath.Base(f))
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("读取迁移文件 %s 失败: %w", f, err)
+		}
+		sum := sha256.Sum256(data)
+
+		statuses = append(statuses, MigrationStatus{
+			Version:  uint(version),
+			Name:     match[2],
+			Applied:  uint(version) <= currentVersion,
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// Redo 在单个事务内回滚并重新应用最新一次迁移：先执行down.sql，再执行
+// up.sql，任意一步失败都回滚整个事务，避免数据库停留在中间状态——这比
+// 直接调用 Down(1) 后 Up() 更安全，因为golang-migrate本身不会跨这两步
+// 共享事务
+func (mm *MigrationManager) Redo() error {
+	currentVersion, dirty, err := mm.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("当前处于dirty状态，无法安全redo")
+	}
+	if currentVersion == 0 {
+		return fmt.Errorf("没有已应用的迁移可供redo")
+	}
+
+	downSQL, upSQL, err := mm.loadMigrationPair(currentVersion)
+	if err != nil {
+		return err
+	}
+
+	tx, err := mm.db.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+
+	if _, err := tx.Exec(downSQL); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("redo回滚阶段失败: %w", err)
+	}
+	if _, err := tx.Exec(upSQL); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("redo重新应用阶段失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交redo事务失败: %w", err)
+	}
+
+	return nil
+}
+
+func (mm *MigrationManager) loadMigrationPair(version uint) (downSQL, upSQL string, err error) {
+	versionStr := fmt.Sprintf("%06d", version)
+
+	downFiles, err := filepath.Glob(filepath.Join(mm.migrationsDir, versionStr+"_*.down.sql"))
+	if err != nil || len(downFiles) == 0 {
+		return "", "", fmt.Errorf("未找到版本 %d 的down迁移文件", version)
+	}
+	upFiles, err := filepath.Glob(filepath.Join(mm.migrationsDir, versionStr+"_*.up.sql"))
+	if err != nil || len(upFiles) == 0 {
+		return "", "", fmt.Errorf("未找到版本 %d 的up迁移文件", version)
+	}
+
+	downData, err := os.ReadFile(downFiles[0])
+	if err != nil {
+		return "", "", fmt.Errorf("读取 %s 失败: %w", downFiles[0], err)
+	}
+	upData, err := os.ReadFile(upFiles[0])
+	if err != nil {
+		return "", "", fmt.Errorf("读取 %s 失败: %w", upFiles[0], err)
+	}
+
+	return string(downData), string(upData), nil
+}
+
+// DryRunPlan 是对一条迁移SQL语句的dry-run结果：能用EXPLAIN的语句（纯
+// DML）记录其查询计划，DDL语句（CREATE/ALTER/DROP等PostgreSQL不支持
+// EXPLAIN的语句）则直接在回滚事务内执行以验证其有效性，Plan里放一条
+// 说明而不是计划文本。事务始终会回滚，不会对数据库产生实际影响
+type DryRunPlan struct {
+	Statement string   `json:"statement"`
+	Plan      []string `json:"plan"`
+}
+
+// DryRunUp 在一个事务内逐条处理up.sql的每条语句（DML用EXPLAIN捕获计划，
+// DDL直接执行），执行完毕后无条件回滚，用于在不改变数据库的前提下预览
+// up操作的影响
+func (mm *MigrationManager) DryRunUp(version uint) ([]DryRunPlan, error) {
+	_, upSQL, err := mm.loadMigrationPair(version)
+	if err != nil {
+		return nil, err
+	}
+	return mm.dryRunSQL(upSQL)
+}
+
+// DryRunDown 同DryRunUp，但面向down.sql
+func (mm *MigrationManager) DryRunDown(version uint) ([]DryRunPlan, error) {
+	downSQL, _, err := mm.loadMigrationPair(version)
+	if err != nil {
+		return nil, err
+	}
+	return mm.dryRunSQL(downSQL)
+}
+
+// explainablePrefixes 是PostgreSQL的EXPLAIN支持的语句类型；仓库里的迁移
+// 文件几乎全是DDL（CREATE TABLE/CREATE EXTENSION/CREATE INDEX等），这些
+// 语句EXPLAIN会直接报语法错误，必须改为在回滚事务里真正执行
+var explainablePrefixes = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "WITH"}
+
+func isExplainable(stmt string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(stmt))
+	for _, prefix := range explainablePrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mm *MigrationManager) dryRunSQL(sqlText string) ([]DryRunPlan, error) {
+	tx, err := mm.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	var plans []DryRunPlan
+	for _, stmt := range splitStatements(sqlText) {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+
+		if !isExplainable(stmt) {
+			if _, err := tx.Exec(stmt); err != nil {
+				return nil, fmt.Errorf("执行语句失败: %w", err)
+			}
+			plans = append(plans, DryRunPlan{
+				Statement: stmt,
+				Plan:      []string{"DDL语句，已在回滚事务内执行以验证有效性，无查询计划"},
+			})
+			continue
+		}
+
+		rows, err := tx.Query("EXPLAIN " + stmt)
+		if err != nil {
+			return nil, fmt.Errorf("对语句执行EXPLAIN失败: %w", err)
+		}
+
+		var lines []string
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("读取查询计划失败: %w", err)
+			}
+			lines = append(lines, line)
+		}
+		rows.Close()
+
+		plans = append(plans, DryRunPlan{Statement: stmt, Plan: lines})
+	}
+
+	return plans, nil
+}
+
+// splitStatements 按分号切分迁移文件中的多条SQL语句；迁移文件里不使用
+// 包含分号的字符串字面量，因此简单按分号切分即可满足dry-run预览的需要
+func splitStatements(sqlText string) []string {
+	return strings.Split(sqlText, ";")
+}
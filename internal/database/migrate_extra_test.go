@@ -0,0 +1,65 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDryRunUpExecutesRealDDLMigration 针对仓库里真实的000001迁移做
+// dry-run，覆盖回归点：该迁移全是CREATE TABLE/CREATE EXTENSION/CREATE
+// INDEX等DDL，早期实现对每条语句都调用EXPLAIN会直接报语法错误
+func TestDryRunUpExecutesRealDDLMigration(t *testing.T) {
+	config := LoadConfigFromEnv()
+	db, err := Connect(config)
+	if err != nil {
+		t.Skipf("没有可用的数据库连接，跳过dry-run测试: %v", err)
+	}
+	defer db.Close()
+
+	dbURL := "postgres://" + config.User + ":" + config.Password + "@" +
+		config.Host + "/" + config.DBName + "?sslmode=" + config.SSLMode
+
+	mm, err := NewMigrationManager(db, dbURL, "../../migrations")
+	if err != nil {
+		t.Fatalf("创建迁移管理器失败: %v", err)
+	}
+	defer mm.Close()
+
+	plans, err := mm.DryRunUp(1)
+	if err != nil {
+		t.Fatalf("dry-run 000001迁移失败: %v", err)
+	}
+	if len(plans) == 0 {
+		t.Fatalf("期望至少得到一条语句的dry-run结果")
+	}
+
+	var sawDDL bool
+	for _, p := range plans {
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(p.Statement)), "CREATE") {
+			sawDDL = true
+			if len(p.Plan) == 0 || !strings.Contains(p.Plan[0], "DDL") {
+				t.Fatalf("CREATE语句应走DDL直接执行分支，得到: %v", p.Plan)
+			}
+		}
+	}
+	if !sawDDL {
+		t.Fatalf("期望000001迁移里包含CREATE语句")
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	stmts := splitStatements("SELECT 1;\nSELECT 2;\n")
+	if len(stmts) != 3 {
+		t.Fatalf("期望3个分片（含末尾只有换行符的分片），得到%d个", len(stmts))
+	}
+}
+
+func TestMigrationFileRegexpMatchesVersionAndName(t *testing.T) {
+	match := migrationFileRe.FindStringSubmatch("000002_compression_retention_policies.up.sql")
+	if match == nil {
+		t.Fatalf("期望匹配成功")
+	}
+	if match[1] != "000002" || match[2] != "compression_retention_policies" {
+		t.Fatalf("解析结果不正确: %v", match)
+	}
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// TimescaleStats 是TimescaleDB特有的超表统计信息，供 /metrics 导出为
+// Prometheus指标使用
+type TimescaleStats struct {
+	Hypertable       string  `json:"hypertable"`
+	TotalChunks      int     `json:"total_chunks"`
+	CompressedChunks int     `json:"compressed_chunks"`
+	CompressionRatio float64 `json:"compression_ratio"`
+}
+
+// GetTimescaleStats 查询每个已知超表的chunk数量与压缩比
+func GetTimescaleStats(ctx context.Context, db *sql.DB) ([]TimescaleStats, error) {
+	hypertables := []string{"ticks", "klines_1m", "signals"}
+	stats := make([]TimescaleStats, 0, len(hypertables))
+
+	for _, name := range hypertables {
+		s, err := getHypertableStats(ctx, db, name)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
+
+func getHypertableStats(ctx context.Context, db *sql.DB, hypertable string) (TimescaleStats, error) {
+	s := TimescaleStats{Hypertable: hypertable}
+
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			count(*) AS total_chunks,
+			count(*) FILTER (WHERE is_compressed) AS compressed_chunks
+		FROM timescaledb_information.chunks
+		WHERE hypertable_name = $1`, hypertable,
+	).Scan(&s.TotalChunks, &s.CompressedChunks)
+	if err != nil {
+		return s, fmt.Errorf("查询超表 %s 的chunk统计失败: %w", hypertable, err)
+	}
+
+	var beforeBytes, afterBytes sql.NullInt64
+	err = db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE(sum(before_compression_total_bytes), 0),
+			COALESCE(sum(after_compression_total_bytes), 0)
+		FROM chunk_compression_stats($1)`, hypertable,
+	).Scan(&beforeBytes, &afterBytes)
+	if err != nil {
+		// 压缩统计函数在未启用压缩策略时可能不可用，不视为致命错误
+		return s, nil
+	}
+
+	if beforeBytes.Int64 > 0 {
+		s.CompressionRatio = float64(afterBytes.Int64) / float64(beforeBytes.Int64)
+	}
+	return s, nil
+}
@@ -0,0 +1,30 @@
+package exchange
+
+import "time"
+
+// Backoff 实现带上限的指数退避，用于WebSocket断线重连
+type Backoff struct {
+	Base    time.Duration
+	Max     time.Duration
+	attempt int
+}
+
+// NewBackoff 创建一个退避计算器
+func NewBackoff(base, max time.Duration) *Backoff {
+	return &Backoff{Base: base, Max: max}
+}
+
+// Next 返回下一次重连前应等待的时长，并递增内部计数
+func (b *Backoff) Next() time.Duration {
+	wait := b.Base << b.attempt
+	if wait <= 0 || wait > b.Max {
+		wait = b.Max
+	}
+	b.attempt++
+	return wait
+}
+
+// Reset 在成功连接后重置退避计数
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
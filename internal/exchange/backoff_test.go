@@ -0,0 +1,39 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffNextGrowsAndCaps(t *testing.T) {
+	b := NewBackoff(100*time.Millisecond, time.Second)
+
+	first := b.Next()
+	if first != 100*time.Millisecond {
+		t.Fatalf("第一次退避时长应等于base，得到 %v", first)
+	}
+
+	second := b.Next()
+	if second != 200*time.Millisecond {
+		t.Fatalf("第二次退避时长应翻倍，得到 %v", second)
+	}
+
+	for i := 0; i < 10; i++ {
+		b.Next()
+	}
+	capped := b.Next()
+	if capped != time.Second {
+		t.Fatalf("退避时长应被限制在上限，得到 %v", capped)
+	}
+}
+
+func TestBackoffReset(t *testing.T) {
+	b := NewBackoff(100*time.Millisecond, time.Second)
+	b.Next()
+	b.Next()
+	b.Reset()
+
+	if got := b.Next(); got != 100*time.Millisecond {
+		t.Fatalf("重置后应回到base，得到 %v", got)
+	}
+}
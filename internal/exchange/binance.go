@@ -0,0 +1,447 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Market 区分币安现货和U本位合约两套REST/WS端点
+type Market string
+
+const (
+	MarketSpot    Market = "spot"
+	MarketFutures Market = "futures"
+)
+
+const (
+	binanceSpotWSBase      = "wss://stream.binance.com:9443/stream"
+	binanceSpotRESTBase    = "https://api.binance.com"
+	binanceFuturesWSBase   = "wss://fstream.binance.com/stream"
+	binanceFuturesRESTBase = "https://fapi.binance.com"
+)
+
+// BinanceConnector 实现 ExchangeConnector，覆盖币安现货与USDT本位合约
+type BinanceConnector struct {
+	market  Market
+	cfg     Config
+	httpCli *http.Client
+
+	mu      sync.Mutex
+	conns   []*websocket.Conn
+	closed  bool
+}
+
+func init() {
+	Register("binance-spot", func(cfg Config) (ExchangeConnector, error) {
+		return NewBinanceConnector(MarketSpot, cfg), nil
+	})
+	Register("binance-futures", func(cfg Config) (ExchangeConnector, error) {
+		return NewBinanceConnector(MarketFutures, cfg), nil
+	})
+}
+
+// NewBinanceConnector 创建币安连接器
+func NewBinanceConnector(market Market, cfg Config) *BinanceConnector {
+	return &BinanceConnector{
+		market:  market,
+		cfg:     cfg,
+		httpCli: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name 返回连接器标识
+func (b *BinanceConnector) Name() string {
+	if b.market == MarketFutures {
+		return "binance-futures"
+	}
+	return "binance-spot"
+}
+
+func (b *BinanceConnector) wsBase() string {
+	if b.cfg.BaseURL != "" {
+		return b.cfg.BaseURL
+	}
+	if b.market == MarketFutures {
+		return binanceFuturesWSBase
+	}
+	return binanceSpotWSBase
+}
+
+func (b *BinanceConnector) restBase() string {
+	if b.cfg.FuturesURL != "" && b.market == MarketFutures {
+		return b.cfg.FuturesURL
+	}
+	if b.market == MarketFutures {
+		return binanceFuturesRESTBase
+	}
+	return binanceSpotRESTBase
+}
+
+// binanceStreamEnvelope 是组合流（/stream?streams=...）的外层信封
+type binanceStreamEnvelope struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// binanceTradePayload 对应 <symbol>@trade 的载荷
+type binanceTradePayload struct {
+	Symbol    string `json:"s"`
+	Price     string `json:"p"`
+	Quantity  string `json:"q"`
+	TradeTime int64  `json:"T"`
+}
+
+// dialWithBackoff 建立一个自动重连的组合流连接，并把原始消息投递到 raw channel
+func (b *BinanceConnector) dialWithBackoff(ctx context.Context, streams []string, raw chan<- []byte) {
+	bo := NewBackoff(time.Second, 30*time.Second)
+	url := fmt.Sprintf("%s?streams=%s", b.wsBase(), strings.Join(streams, "/"))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+		if err != nil {
+			wait := bo.Next()
+			log.Printf("[%s] WebSocket拨号失败: %v，%s后重试", b.Name(), err, wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+				continue
+			}
+		}
+
+		b.mu.Lock()
+		b.conns = append(b.conns, conn)
+		b.mu.Unlock()
+		bo.Reset()
+		log.Printf("[%s] WebSocket已连接: %s", b.Name(), url)
+
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				log.Printf("[%s] WebSocket读取失败: %v", b.Name(), err)
+				break
+			}
+			select {
+			case raw <- msg:
+			case <-ctx.Done():
+				conn.Close()
+				return
+			}
+		}
+
+		conn.Close()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(bo.Next()):
+		}
+	}
+}
+
+// SubscribeTrades 订阅逐笔成交并归一化为 MarketData
+func (b *BinanceConnector) SubscribeTrades(ctx context.Context, symbols []string) (<-chan MarketData, error) {
+	streams := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		streams = append(streams, strings.ToLower(s)+"@trade")
+	}
+
+	raw := make(chan []byte, 256)
+	out := make(chan MarketData, 256)
+
+	go b.dialWithBackoff(ctx, streams, raw)
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-raw:
+				var env binanceStreamEnvelope
+				if err := json.Unmarshal(msg, &env); err != nil {
+					log.Printf("[%s] 解析信封失败: %v", b.Name(), err)
+					continue
+				}
+				var trade binanceTradePayload
+				if err := json.Unmarshal(env.Data, &trade); err != nil {
+					log.Printf("[%s] 解析成交数据失败: %v", b.Name(), err)
+					continue
+				}
+				price, _ := strconv.ParseFloat(trade.Price, 64)
+				qty, _ := strconv.ParseFloat(trade.Quantity, 64)
+				data := MarketData{
+					Symbol:    trade.Symbol,
+					Price:     price,
+					Volume:    qty,
+					Timestamp: time.UnixMilli(trade.TradeTime),
+					Type:      "trade",
+				}
+				select {
+				case out <- data:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// binanceKlinePayload 对应 <symbol>@kline_<interval> 的载荷
+type binanceKlinePayload struct {
+	Kline struct {
+		StartTime int64  `json:"t"`
+		EndTime   int64  `json:"T"`
+		Symbol    string `json:"s"`
+		Interval  string `json:"i"`
+		Open      string `json:"o"`
+		Close     string `json:"c"`
+		High      string `json:"h"`
+		Low       string `json:"l"`
+		Volume    string `json:"v"`
+		IsClosed  bool   `json:"x"`
+	} `json:"k"`
+}
+
+// SubscribeKlines 订阅指定周期的K线
+func (b *BinanceConnector) SubscribeKlines(ctx context.Context, symbols []string, interval string) (<-chan Kline, error) {
+	streams := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@kline_%s", strings.ToLower(s), interval))
+	}
+
+	raw := make(chan []byte, 256)
+	out := make(chan Kline, 256)
+
+	go b.dialWithBackoff(ctx, streams, raw)
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-raw:
+				var env binanceStreamEnvelope
+				if err := json.Unmarshal(msg, &env); err != nil {
+					log.Printf("[%s] 解析信封失败: %v", b.Name(), err)
+					continue
+				}
+				var payload binanceKlinePayload
+				if err := json.Unmarshal(env.Data, &payload); err != nil {
+					log.Printf("[%s] 解析K线数据失败: %v", b.Name(), err)
+					continue
+				}
+				k := payload.Kline
+				open, _ := strconv.ParseFloat(k.Open, 64)
+				closeP, _ := strconv.ParseFloat(k.Close, 64)
+				high, _ := strconv.ParseFloat(k.High, 64)
+				low, _ := strconv.ParseFloat(k.Low, 64)
+				vol, _ := strconv.ParseFloat(k.Volume, 64)
+				select {
+				case out <- Kline{
+					Symbol:    k.Symbol,
+					Interval:  k.Interval,
+					OpenTime:  time.UnixMilli(k.StartTime),
+					CloseTime: time.UnixMilli(k.EndTime),
+					Open:      open,
+					High:      high,
+					Low:       low,
+					Close:     closeP,
+					Volume:    vol,
+					Closed:    k.IsClosed,
+				}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// binanceDepthPayload 对应 <symbol>@depth 的载荷
+type binanceDepthPayload struct {
+	Symbol string     `json:"s"`
+	Bids   [][]string `json:"b"`
+	Asks   [][]string `json:"a"`
+}
+
+// SubscribeDepth 订阅订单簿深度
+func (b *BinanceConnector) SubscribeDepth(ctx context.Context, symbols []string) (<-chan Depth, error) {
+	streams := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		streams = append(streams, strings.ToLower(s)+"@depth20@100ms")
+	}
+
+	raw := make(chan []byte, 256)
+	out := make(chan Depth, 256)
+
+	go b.dialWithBackoff(ctx, streams, raw)
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-raw:
+				var env binanceStreamEnvelope
+				if err := json.Unmarshal(msg, &env); err != nil {
+					log.Printf("[%s] 解析信封失败: %v", b.Name(), err)
+					continue
+				}
+				var payload binanceDepthPayload
+				if err := json.Unmarshal(env.Data, &payload); err != nil {
+					log.Printf("[%s] 解析深度数据失败: %v", b.Name(), err)
+					continue
+				}
+				depth := Depth{
+					Symbol:    payload.Symbol,
+					Bids:      parseDepthLevels(payload.Bids),
+					Asks:      parseDepthLevels(payload.Asks),
+					Timestamp: time.Now(),
+				}
+				select {
+				case out <- depth:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func parseDepthLevels(levels [][]string) []DepthLevel {
+	out := make([]DepthLevel, 0, len(levels))
+	for _, lvl := range levels {
+		if len(lvl) != 2 {
+			continue
+		}
+		price, _ := strconv.ParseFloat(lvl[0], 64)
+		qty, _ := strconv.ParseFloat(lvl[1], 64)
+		out = append(out, DepthLevel{Price: price, Quantity: qty})
+	}
+	return out
+}
+
+// binanceExchangeInfo 是 /exchangeInfo 响应中与精度相关的子集
+type binanceExchangeInfo struct {
+	Symbols []struct {
+		Symbol     string `json:"symbol"`
+		Filters    []struct {
+			FilterType  string `json:"filterType"`
+			TickSize    string `json:"tickSize"`
+			StepSize    string `json:"stepSize"`
+			MinNotional string `json:"minNotional"`
+			Notional    string `json:"notional"`
+		} `json:"filters"`
+		ContractType string `json:"contractType"`
+	} `json:"symbols"`
+}
+
+// GetContractInfo 拉取单个交易对的价格精度/数量精度/最小名义价值
+func (b *BinanceConnector) GetContractInfo(ctx context.Context, symbol string) (*FuturesContractInfo, error) {
+	path := "/api/v3/exchangeInfo"
+	if b.market == MarketFutures {
+		path = "/fapi/v1/exchangeInfo"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.restBase()+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("构建请求失败: %w", err)
+	}
+
+	resp, err := b.httpCli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求交易规则失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("交易规则接口返回非200状态码: %d", resp.StatusCode)
+	}
+
+	var info binanceExchangeInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("解析交易规则失败: %w", err)
+	}
+
+	for _, s := range info.Symbols {
+		if !strings.EqualFold(s.Symbol, symbol) {
+			continue
+		}
+		contractInfo := &FuturesContractInfo{
+			Symbol:       s.Symbol,
+			ContractType: ContractTypeSpot,
+			ContractVal:  1,
+		}
+		if b.market == MarketFutures {
+			contractInfo.ContractType = ContractTypePerpetual
+			if s.ContractType != "" {
+				contractInfo.ContractType = ContractType(s.ContractType)
+			}
+		}
+		for _, f := range s.Filters {
+			switch f.FilterType {
+			case "PRICE_FILTER":
+				contractInfo.PriceTickSize, _ = strconv.ParseFloat(f.TickSize, 64)
+			case "LOT_SIZE":
+				contractInfo.AmountTickSize, _ = strconv.ParseFloat(f.StepSize, 64)
+			case "MIN_NOTIONAL":
+				contractInfo.MinNotional, _ = strconv.ParseFloat(f.MinNotional, 64)
+			case "NOTIONAL":
+				contractInfo.MinNotional, _ = strconv.ParseFloat(f.Notional, 64)
+			}
+		}
+		return contractInfo, nil
+	}
+
+	return nil, fmt.Errorf("未在交易规则中找到交易对: %s", symbol)
+}
+
+// PlaceOrder 下单（占位实现：签名下单需要完整的API密钥管理，留待下一轮迭代接入）
+func (b *BinanceConnector) PlaceOrder(ctx context.Context, req OrderRequest) (*OrderResult, error) {
+	if b.cfg.APIKey == "" || b.cfg.APISecret == "" {
+		return nil, fmt.Errorf("下单需要配置 API Key/Secret")
+	}
+	return nil, fmt.Errorf("币安签名下单尚未实现")
+}
+
+// Close 关闭所有已建立的WebSocket连接
+func (b *BinanceConnector) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	var firstErr error
+	for _, conn := range b.conns {
+		if err := conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
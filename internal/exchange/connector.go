@@ -0,0 +1,133 @@
+// Package exchange 定义交易所连接器的统一接口及其注册机制
+package exchange
+
+import (
+	"context"
+	"time"
+)
+
+// MarketData 市场数据结构（与 examples 中的行情结构保持字段一致）
+type MarketData struct {
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price"`
+	Volume    float64   `json:"volume"`
+	Timestamp time.Time `json:"timestamp"`
+	Type      string    `json:"type"`
+}
+
+// Kline K线（蜡烛图）数据
+type Kline struct {
+	Symbol    string    `json:"symbol"`
+	Interval  string    `json:"interval"`
+	OpenTime  time.Time `json:"open_time"`
+	CloseTime time.Time `json:"close_time"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+	Closed    bool      `json:"closed"`
+}
+
+// DepthLevel 订单簿的单档深度
+type DepthLevel struct {
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+}
+
+// Depth 订单簿深度快照
+type Depth struct {
+	Symbol    string       `json:"symbol"`
+	Bids      []DepthLevel `json:"bids"`
+	Asks      []DepthLevel `json:"asks"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// PositionSide 持仓方向，支持对冲模式下的合约交易
+type PositionSide string
+
+const (
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+	PositionSideBoth  PositionSide = "BOTH"
+)
+
+// ContractType 合约类型
+type ContractType string
+
+const (
+	ContractTypeSpot      ContractType = "SPOT"
+	ContractTypePerpetual ContractType = "PERPETUAL"
+	ContractTypeDelivery  ContractType = "DELIVERY"
+)
+
+// FuturesContractInfo 合约信息，供下游策略据此对订单价格/数量做精度对齐
+type FuturesContractInfo struct {
+	Symbol        string       `json:"symbol"`
+	ContractType  ContractType `json:"contract_type"`
+	PriceTickSize  float64     `json:"price_tick_size"`
+	AmountTickSize float64     `json:"amount_tick_size"`
+	ContractVal    float64     `json:"contract_val"`
+	MinNotional    float64     `json:"min_notional"`
+}
+
+// OrderSide 订单方向
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "BUY"
+	OrderSideSell OrderSide = "SELL"
+)
+
+// OrderType 订单类型
+type OrderType string
+
+const (
+	OrderTypeMarket OrderType = "MARKET"
+	OrderTypeLimit  OrderType = "LIMIT"
+)
+
+// OrderRequest 下单请求
+type OrderRequest struct {
+	Symbol       string       `json:"symbol"`
+	Side         OrderSide    `json:"side"`
+	Type         OrderType    `json:"type"`
+	PositionSide PositionSide `json:"position_side"`
+	Price        float64      `json:"price"`
+	Quantity     float64      `json:"quantity"`
+	ClientID     string       `json:"client_id"`
+}
+
+// OrderResult 下单结果
+type OrderResult struct {
+	OrderID      string    `json:"order_id"`
+	ClientID     string    `json:"client_id"`
+	Status       string    `json:"status"`
+	FilledQty    float64   `json:"filled_qty"`
+	AvgPrice     float64   `json:"avg_price"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ExchangeConnector 是所有交易所适配器必须实现的统一接口
+type ExchangeConnector interface {
+	// Name 返回连接器标识，例如 "binance-spot"、"binance-futures"
+	Name() string
+
+	// SubscribeTrades 订阅逐笔成交，并把归一化后的 MarketData 推送到返回的 channel
+	SubscribeTrades(ctx context.Context, symbols []string) (<-chan MarketData, error)
+
+	// SubscribeKlines 订阅指定周期的K线
+	SubscribeKlines(ctx context.Context, symbols []string, interval string) (<-chan Kline, error)
+
+	// SubscribeDepth 订阅订单簿深度
+	SubscribeDepth(ctx context.Context, symbols []string) (<-chan Depth, error)
+
+	// GetContractInfo 获取合约的精度/最小名义价值等信息
+	GetContractInfo(ctx context.Context, symbol string) (*FuturesContractInfo, error)
+
+	// PlaceOrder 下单
+	PlaceOrder(ctx context.Context, req OrderRequest) (*OrderResult, error)
+
+	// Close 释放连接器持有的所有连接/协程
+	Close() error
+}
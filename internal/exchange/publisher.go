@@ -0,0 +1,66 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/nats-io/nats.go"
+)
+
+// PublishMarketData 把连接器产出的行情流桥接到 JetStream，落地到与现有
+// market.prices.<symbol> 约定兼容的主题上，并额外发布成交/深度主题
+func PublishMarketData(ctx context.Context, js nats.JetStreamContext, conn ExchangeConnector, symbols []string) error {
+	trades, err := conn.SubscribeTrades(ctx, symbols)
+	if err != nil {
+		return fmt.Errorf("订阅成交流失败: %w", err)
+	}
+
+	depths, err := conn.SubscribeDepth(ctx, symbols)
+	if err != nil {
+		return fmt.Errorf("订阅深度流失败: %w", err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case trade, ok := <-trades:
+				if !ok {
+					return
+				}
+				publishJSON(js, fmt.Sprintf("market.prices.%s", trade.Symbol), trade)
+				publishJSON(js, fmt.Sprintf("market.trades.%s", trade.Symbol), trade)
+			}
+		}
+	}()
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case depth, ok := <-depths:
+				if !ok {
+					return
+				}
+				publishJSON(js, fmt.Sprintf("market.depth.%s", depth.Symbol), depth)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func publishJSON(js nats.JetStreamContext, subject string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("序列化发布数据失败 (%s): %v", subject, err)
+		return
+	}
+	if _, err := js.Publish(subject, data); err != nil {
+		log.Printf("发布到 %s 失败: %v", subject, err)
+	}
+}
@@ -0,0 +1,53 @@
+package exchange
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Factory 根据配置创建一个 ExchangeConnector 实例
+type Factory func(cfg Config) (ExchangeConnector, error)
+
+// Config 连接器的通用配置，具体字段由各交易所适配器按需解释
+type Config struct {
+	APIKey     string
+	APISecret  string
+	BaseURL    string
+	FuturesURL string
+	Testnet    bool
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register 注册一个交易所适配器工厂，供 OKX/Bybit 等后续接入时复用
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New 按名称创建一个已注册的连接器
+func New(name string, cfg Config) (ExchangeConnector, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("未注册的交易所连接器: %s", name)
+	}
+	return factory(cfg)
+}
+
+// Registered 返回当前已注册的连接器名称列表
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// JetStreamTarget 描述一个需要被深度健康检查覆盖的流/消费者组合
+type JetStreamTarget struct {
+	StreamName      string
+	ConsumerName    string
+	MaxLag          uint64
+	MaxPendingBytes uint64
+}
+
+// JetStreamChecker 对一组流/消费者做比NATSChecker更深入的检查：不仅确认
+// 连接本身是否存活，还检查每个流是否有leader、副本是否同步、消费者的
+// 待处理消息数是否超过MaxLag、流中保存的总字节数是否超过MaxPendingBytes
+type JetStreamChecker struct {
+	js      nats.JetStreamContext
+	targets []JetStreamTarget
+}
+
+// NewJetStreamChecker 创建一个检查给定targets的健康检查器
+func NewJetStreamChecker(js nats.JetStreamContext, targets []JetStreamTarget) *JetStreamChecker {
+	return &JetStreamChecker{js: js, targets: targets}
+}
+
+// Check 依次检查每个target；任意流没有leader或ConsumerInfo报错都判定为
+// 不健康，待处理量超阈值或副本不同步则判定为降级
+func (j *JetStreamChecker) Check(ctx context.Context) CheckResult {
+	start := time.Now()
+	result := CheckResult{Name: "jetstream", Timestamp: start}
+
+	if len(j.targets) == 0 {
+		result.Status = StatusHealthy
+		result.Message = "没有配置需要深度检查的流"
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	streams := make(map[string]interface{})
+	degraded := false
+	unhealthy := false
+	var messages []string
+
+	for _, target := range j.targets {
+		streamInfo, err := j.js.StreamInfo(target.StreamName)
+		if err != nil {
+			unhealthy = true
+			messages = append(messages, fmt.Sprintf("流 %s 查询失败: %v", target.StreamName, err))
+			continue
+		}
+
+		streamMeta := map[string]interface{}{
+			"messages": streamInfo.State.Msgs,
+			"bytes":    streamInfo.State.Bytes,
+		}
+
+		if streamInfo.Cluster != nil {
+			streamMeta["leader"] = streamInfo.Cluster.Leader
+			if streamInfo.Cluster.Leader == "" {
+				unhealthy = true
+				messages = append(messages, fmt.Sprintf("流 %s 没有leader", target.StreamName))
+			}
+
+			inSync := 0
+			for _, replica := range streamInfo.Cluster.Replicas {
+				if replica.Current && replica.Lag <= target.MaxLag {
+					inSync++
+				}
+			}
+			streamMeta["replicas_in_sync"] = inSync
+			streamMeta["replicas_total"] = len(streamInfo.Cluster.Replicas)
+			if inSync < len(streamInfo.Cluster.Replicas) {
+				degraded = true
+				messages = append(messages, fmt.Sprintf("流 %s 存在落后副本", target.StreamName))
+			}
+		}
+
+		if target.ConsumerName != "" {
+			consumerInfo, err := j.js.ConsumerInfo(target.StreamName, target.ConsumerName)
+			if err != nil {
+				unhealthy = true
+				messages = append(messages, fmt.Sprintf("消费者 %s/%s 查询失败: %v", target.StreamName, target.ConsumerName, err))
+			} else {
+				streamMeta["consumer_pending"] = consumerInfo.NumPending
+				streamMeta["consumer_ack_pending"] = consumerInfo.NumAckPending
+				streamMeta["consumer_redelivered"] = consumerInfo.NumRedelivered
+
+				if target.MaxLag > 0 && consumerInfo.NumPending > target.MaxLag {
+					degraded = true
+					messages = append(messages, fmt.Sprintf("消费者 %s/%s 积压超过阈值(%d>%d)", target.StreamName, target.ConsumerName, consumerInfo.NumPending, target.MaxLag))
+				}
+				if target.MaxPendingBytes > 0 && uint64(streamInfo.State.Bytes) > target.MaxPendingBytes {
+					degraded = true
+					messages = append(messages, fmt.Sprintf("流 %s 待处理字节数超过阈值", target.StreamName))
+				}
+			}
+		}
+
+		streams[target.StreamName] = streamMeta
+	}
+
+	result.Metadata = map[string]interface{}{"streams": streams}
+	result.Duration = time.Since(start)
+
+	switch {
+	case unhealthy:
+		result.Status = StatusUnhealthy
+	case degraded:
+		result.Status = StatusDegraded
+	default:
+		result.Status = StatusHealthy
+	}
+
+	if len(messages) == 0 {
+		result.Message = "所有受检流与消费者均正常"
+	} else {
+		result.Message = fmt.Sprintf("%v", messages)
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/finovatex/finovatex/pkg/natsrpc"
+)
+
+// RPCChecker 对一组已导出的 pkg/natsrpc RPC主题逐个发起ping探测，把不可达
+// 的端点计入不健康列表，使同步RPC端点随 /health 一起被监控
+type RPCChecker struct {
+	client   *natsrpc.Client
+	subjects []string
+	timeout  time.Duration
+}
+
+// NewRPCChecker 创建一个探测给定RPC主题的健康检查器；subjects通常来自
+// natsrpc.ExportedSubjects()
+func NewRPCChecker(client *natsrpc.Client, subjects []string) *RPCChecker {
+	return &RPCChecker{client: client, subjects: subjects, timeout: 3 * time.Second}
+}
+
+// Check 依次ping每个已注册的RPC主题；任意一个不可达就标记为降级，全部
+// 不可达则标记为不健康
+func (r *RPCChecker) Check(ctx context.Context) CheckResult {
+	start := time.Now()
+	result := CheckResult{Name: "rpc", Timestamp: start}
+
+	if len(r.subjects) == 0 {
+		result.Status = StatusHealthy
+		result.Message = "没有已导出的RPC主题"
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	unreachable := map[string]string{}
+	for _, subject := range r.subjects {
+		pingCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		err := r.client.Ping(pingCtx, subject)
+		cancel()
+		if err != nil {
+			unreachable[subject] = err.Error()
+		}
+	}
+
+	result.Duration = time.Since(start)
+	result.Metadata = map[string]interface{}{
+		"total_subjects":       len(r.subjects),
+		"unreachable_subjects": unreachable,
+	}
+
+	switch {
+	case len(unreachable) == 0:
+		result.Status = StatusHealthy
+		result.Message = "所有已导出的RPC端点均可达"
+	case len(unreachable) == len(r.subjects):
+		result.Status = StatusUnhealthy
+		result.Message = "所有已导出的RPC端点均不可达"
+	default:
+		result.Status = StatusDegraded
+		result.Message = fmt.Sprintf("%d/%d 个RPC端点不可达", len(unreachable), len(r.subjects))
+	}
+
+	return result
+}
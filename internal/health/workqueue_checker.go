@@ -0,0 +1,39 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/finovatex/finovatex/pkg/messaging/workqueue"
+)
+
+// WorkQueueChecker 把workqueue.DesyncDetector的最近一次检测结果接入
+// health.Manager，使流副本失步在 /health 上表现为降级
+type WorkQueueChecker struct {
+	detector *workqueue.DesyncDetector
+}
+
+// NewWorkQueueChecker 创建一个读取给定检测器状态的健康检查器
+func NewWorkQueueChecker(detector *workqueue.DesyncDetector) *WorkQueueChecker {
+	return &WorkQueueChecker{detector: detector}
+}
+
+// Check 读取检测器最近一次轮询的结果；不同步时标记为降级
+func (w *WorkQueueChecker) Check(ctx context.Context) CheckResult {
+	start := time.Now()
+	ok, message, metadata := w.detector.Status()
+
+	result := CheckResult{
+		Name:      "workqueue",
+		Message:   message,
+		Timestamp: start,
+		Duration:  time.Since(start),
+		Metadata:  metadata,
+	}
+	if ok {
+		result.Status = StatusHealthy
+	} else {
+		result.Status = StatusDegraded
+	}
+	return result
+}
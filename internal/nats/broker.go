@@ -0,0 +1,204 @@
+// Package nats 把应用代码中反复出现的JetStream启动逻辑收敛成一个支持
+// 异步初始化、初始化完成前缓冲发布/订阅请求的Broker
+package nats
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// pendingPublish 是JetStream尚未就绪时暂存的一次发布请求
+type pendingPublish struct {
+	subject string
+	data    []byte
+}
+
+// pendingSubscribe 是JetStream尚未就绪时暂存的一次订阅请求
+type pendingSubscribe struct {
+	subject string
+	durable string
+	opts    []nats.SubOpt
+	handler func(*nats.Msg)
+}
+
+// Broker 包装 nats.Conn/JetStreamContext，在JetStream集群尚未就绪时把
+// Publish请求缓冲在内存积压队列中，就绪后按顺序排空并切换为直接发布；
+// 就绪前请求的订阅同样会被记录，就绪后统一建立
+type Broker struct {
+	conn *nats.Conn
+
+	mu      sync.Mutex
+	js      nats.JetStreamContext
+	ready   bool
+	backlog []pendingPublish
+	pending []pendingSubscribe
+
+	pollInterval time.Duration
+	stopCh       chan struct{}
+}
+
+// NewBroker 用已建立的NATS连接创建一个Broker
+func NewBroker(conn *nats.Conn) *Broker {
+	return &Broker{conn: conn, pollInterval: 500 * time.Millisecond, stopCh: make(chan struct{})}
+}
+
+// Start 立即返回，并在后台goroutine中轮询 JetStream 的 AccountInfo 直到
+// 集群就绪；就绪后排空积压的发布请求、建立积压的订阅请求，然后关闭done。
+// 调用方可以在Start返回后立即开始调用Publish/Subscribe，无需等待done
+func (b *Broker) Start(done chan error) error {
+	js, err := b.conn.JetStream()
+	if err != nil {
+		return fmt.Errorf("创建JetStream上下文失败: %w", err)
+	}
+
+	b.mu.Lock()
+	b.js = js
+	b.mu.Unlock()
+
+	go b.waitUntilReady(done)
+
+	return nil
+}
+
+func (b *Broker) waitUntilReady(done chan error) {
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		default:
+		}
+
+		b.mu.Lock()
+		js := b.js
+		b.mu.Unlock()
+
+		if _, err := js.AccountInfo(); err == nil {
+			b.markReady()
+			if done != nil {
+				close(done)
+			}
+			return
+		}
+
+		select {
+		case <-b.stopCh:
+			return
+		case <-time.After(b.pollInterval):
+		}
+	}
+}
+
+func (b *Broker) markReady() {
+	b.mu.Lock()
+	b.ready = true
+	backlog := b.backlog
+	b.backlog = nil
+	pending := b.pending
+	b.pending = nil
+	js := b.js
+	b.mu.Unlock()
+
+	for _, p := range backlog {
+		if _, err := js.Publish(p.subject, p.data); err != nil {
+			log.Printf("排空积压发布失败 (%s): %v", p.subject, err)
+		}
+	}
+
+	for _, s := range pending {
+		if err := b.subscribeNow(s); err != nil {
+			log.Printf("建立积压订阅失败 (%s): %v", s.subject, err)
+		}
+	}
+}
+
+// Publish 在JetStream就绪前把消息暂存到内存积压队列，就绪后直接发布
+func (b *Broker) Publish(subject string, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.ready {
+		b.backlog = append(b.backlog, pendingPublish{subject: subject, data: data})
+		return nil
+	}
+
+	_, err := b.js.Publish(subject, data)
+	if err != nil {
+		return fmt.Errorf("发布到 %s 失败: %w", subject, err)
+	}
+	return nil
+}
+
+// Subscribe 在JetStream就绪前记录订阅请求，就绪后统一建立拉取消费者
+func (b *Broker) Subscribe(subject, durable string, handler func(*nats.Msg), opts ...nats.SubOpt) error {
+	req := pendingSubscribe{subject: subject, durable: durable, opts: opts, handler: handler}
+
+	b.mu.Lock()
+	ready := b.ready
+	if !ready {
+		b.pending = append(b.pending, req)
+	}
+	b.mu.Unlock()
+
+	if !ready {
+		return nil
+	}
+
+	return b.subscribeNow(req)
+}
+
+func (b *Broker) subscribeNow(req pendingSubscribe) error {
+	sub, err := b.js.PullSubscribe(req.subject, req.durable, req.opts...)
+	if err != nil {
+		return fmt.Errorf("订阅 %s 失败: %w", req.subject, err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-b.stopCh:
+				sub.Unsubscribe()
+				return
+			default:
+			}
+
+			msgs, err := sub.Fetch(10, nats.MaxWait(time.Second))
+			if err != nil {
+				continue
+			}
+			for _, msg := range msgs {
+				req.handler(msg)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Ready 返回JetStream当前是否已确认就绪
+func (b *Broker) Ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ready
+}
+
+// BacklogLen 返回当前积压但尚未发布的消息数，主要供测试断言使用
+func (b *Broker) BacklogLen() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.backlog)
+}
+
+// Close 停止就绪轮询循环和所有已建立的拉取消费者的fetch循环；不会重复
+// 关闭底层的 nats.Conn，调用方仍需自行关闭连接
+func (b *Broker) Close() {
+	select {
+	case <-b.stopCh:
+		// 已经关闭过
+	default:
+		close(b.stopCh)
+	}
+}
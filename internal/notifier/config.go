@@ -0,0 +1,100 @@
+package notifier
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChannelConfig 描述YAML/环境变量中单个通知渠道的配置
+type ChannelConfig struct {
+	Type        string  `yaml:"type"` // lark, dingtalk, slack, webhook
+	WebhookURL  string  `yaml:"webhook_url"`
+	BuyOnly     bool    `yaml:"buy_only"`
+	MinNotional float64 `yaml:"min_notional"`
+	Errors      bool    `yaml:"errors"`
+	Fills       bool    `yaml:"fills"`
+}
+
+// Config 是通知子系统的根配置
+type Config struct {
+	Channels []ChannelConfig `yaml:"channels"`
+}
+
+// LoadConfigFromFile 从YAML文件加载通知配置；只要设置了渠道类型对应的
+// 环境变量（例如 FINOVATEX_NOTIFIER_LARK_WEBHOOK_URL），它就覆盖文件中的
+// Webhook地址，无论文件里是否已填写
+func LoadConfigFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取通知配置文件失败: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("解析通知配置文件失败: %w", err)
+	}
+
+	for i := range cfg.Channels {
+		ch := &cfg.Channels[i]
+		if envURL := os.Getenv(envKeyFor(ch.Type)); envURL != "" {
+			ch.WebhookURL = envURL
+		}
+		log.Printf("已加载通知渠道: type=%s webhook=%s", ch.Type, maskURL(ch.WebhookURL))
+	}
+
+	return &cfg, nil
+}
+
+func envKeyFor(channelType string) string {
+	switch channelType {
+	case "lark":
+		return "FINOVATEX_NOTIFIER_LARK_WEBHOOK_URL"
+	case "dingtalk":
+		return "FINOVATEX_NOTIFIER_DINGTALK_WEBHOOK_URL"
+	case "slack":
+		return "FINOVATEX_NOTIFIER_SLACK_WEBHOOK_URL"
+	default:
+		return "FINOVATEX_NOTIFIER_WEBHOOK_URL"
+	}
+}
+
+// BuildMultiNotifier 依据配置构造一个装配好所有渠道的MultiNotifier
+func BuildMultiNotifier(cfg *Config) (*MultiNotifier, error) {
+	m := NewMultiNotifier()
+
+	for _, ch := range cfg.Channels {
+		if ch.WebhookURL == "" {
+			log.Printf("跳过通知渠道 %s：未配置Webhook地址", ch.Type)
+			continue
+		}
+
+		var n Notifier
+		switch ch.Type {
+		case "lark":
+			n = NewLarkNotifier(ch.WebhookURL)
+		case "dingtalk":
+			n = NewDingTalkNotifier(ch.WebhookURL)
+		case "slack":
+			n = NewSlackNotifier(ch.WebhookURL)
+		case "webhook":
+			n = NewGenericWebhookNotifier(ch.WebhookURL)
+		default:
+			return nil, fmt.Errorf("未知的通知渠道类型: %s", ch.Type)
+		}
+
+		filter := AllSignals
+		if ch.BuyOnly {
+			filter = BuyOnly
+		}
+		if ch.MinNotional > 0 {
+			filter = combineFilters(filter, MinNotional(ch.MinNotional))
+		}
+
+		m.AddChannel(n, filter, ch.Errors, ch.Fills)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// dingTalkPayloadBuilder 生成钉钉自定义机器人要求的文本负载
+type dingTalkPayloadBuilder struct{}
+
+func (dingTalkPayloadBuilder) SignalPayload(signal strategy.TradingSignal) any {
+	return map[string]any{
+		"msgtype": "text",
+		"text": map[string]string{
+			"content": fmt.Sprintf("[交易信号] %s %s @ %.8f (策略: %s)",
+				signal.Action, signal.Symbol, signal.Price, signal.StrategyID),
+		},
+	}
+}
+
+func (dingTalkPayloadBuilder) ErrorPayload(err error, fields map[string]any) any {
+	return map[string]any{
+		"msgtype": "text",
+		"text": map[string]string{
+			"content": fmt.Sprintf("[系统错误] %v (上下文: %v)", err, fields),
+		},
+	}
+}
+
+func (dingTalkPayloadBuilder) FillPayload(fill Fill) any {
+	return map[string]any{
+		"msgtype": "text",
+		"text": map[string]string{
+			"content": fmt.Sprintf("[成交回报] %s %s %.8f @ %.8f", fill.Side, fill.Symbol, fill.Quantity, fill.Price),
+		},
+	}
+}
+
+// NewDingTalkNotifier 创建钉钉自定义机器人Webhook通知器
+func NewDingTalkNotifier(webhookURL string) *WebhookNotifier {
+	return NewWebhookNotifier("dingtalk", webhookURL, dingTalkPayloadBuilder{})
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// LogHook 是一个logrus.Hook，把Error及以上级别的日志自动转发给Notifier的
+// 错误通道，从而让 log.Errorf 风格的调用自动触发外部告警
+type LogHook struct {
+	notifier Notifier
+}
+
+// NewLogHook 创建日志告警Hook
+func NewLogHook(n Notifier) *LogHook {
+	return &LogHook{notifier: n}
+}
+
+// Levels 只处理Error及以上级别
+func (h *LogHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.ErrorLevel, logrus.FatalLevel, logrus.PanicLevel}
+}
+
+// Fire 在命中Levels()中的级别时被logrus调用
+func (h *LogHook) Fire(entry *logrus.Entry) error {
+	fields := make(map[string]any, len(entry.Data))
+	for k, v := range entry.Data {
+		fields[k] = v
+	}
+	fields["level"] = entry.Level.String()
+
+	return h.notifier.NotifyError(errorFromEntry(entry), fields)
+}
+
+// entryError 把一条logrus日志包装成error，保留原始消息文本
+type entryError struct {
+	message string
+}
+
+func (e *entryError) Error() string {
+	return e.message
+}
+
+func errorFromEntry(entry *logrus.Entry) error {
+	return &entryError{message: entry.Message}
+}
@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// larkPayloadBuilder 生成飞书自定义机器人要求的文本卡片负载
+type larkPayloadBuilder struct{}
+
+func (larkPayloadBuilder) SignalPayload(signal strategy.TradingSignal) any {
+	return map[string]any{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": fmt.Sprintf("[交易信号] %s %s @ %.8f (策略: %s)",
+				signal.Action, signal.Symbol, signal.Price, signal.StrategyID),
+		},
+	}
+}
+
+func (larkPayloadBuilder) ErrorPayload(err error, fields map[string]any) any {
+	return map[string]any{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": fmt.Sprintf("[系统错误] %v (上下文: %v)", err, fields),
+		},
+	}
+}
+
+func (larkPayloadBuilder) FillPayload(fill Fill) any {
+	return map[string]any{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": fmt.Sprintf("[成交回报] %s %s %.8f @ %.8f", fill.Side, fill.Symbol, fill.Quantity, fill.Price),
+		},
+	}
+}
+
+// NewLarkNotifier 创建飞书自定义机器人Webhook通知器
+func NewLarkNotifier(webhookURL string) *WebhookNotifier {
+	return NewWebhookNotifier("lark", webhookURL, larkPayloadBuilder{})
+}
@@ -0,0 +1,113 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// SignalFilter 决定一条交易信号是否应推送到某个渠道
+type SignalFilter func(signal strategy.TradingSignal) bool
+
+// channel 绑定一个Notifier与其信号过滤条件
+type channel struct {
+	notifier   Notifier
+	signalFilt SignalFilter
+	errors     bool
+	fills      bool
+}
+
+// MultiNotifier 把事件扇出到多个配置的通知渠道，每个渠道可独立过滤
+type MultiNotifier struct {
+	channels []channel
+}
+
+// NewMultiNotifier 创建一个空的多渠道通知器
+func NewMultiNotifier() *MultiNotifier {
+	return &MultiNotifier{}
+}
+
+// AddChannel 注册一个通知渠道
+//   - signalFilt 为 nil 表示该渠道不接收信号
+//   - errors/fills 控制该渠道是否接收系统错误/成交回报
+func (m *MultiNotifier) AddChannel(n Notifier, signalFilt SignalFilter, errors, fills bool) {
+	m.channels = append(m.channels, channel{notifier: n, signalFilt: signalFilt, errors: errors, fills: fills})
+}
+
+// NotifySignal 把信号扇出给所有通过过滤条件的渠道，聚合所有失败
+func (m *MultiNotifier) NotifySignal(signal strategy.TradingSignal) error {
+	var errs []error
+	for _, ch := range m.channels {
+		if ch.signalFilt == nil || !ch.signalFilt(signal) {
+			continue
+		}
+		if err := ch.notifier.NotifySignal(signal); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// NotifyError 把错误扇出给所有订阅了错误通道的渠道
+func (m *MultiNotifier) NotifyError(err error, fields map[string]any) error {
+	var errs []error
+	for _, ch := range m.channels {
+		if !ch.errors {
+			continue
+		}
+		if notifyErr := ch.notifier.NotifyError(err, fields); notifyErr != nil {
+			errs = append(errs, notifyErr)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// NotifyOrderFill 把成交回报扇出给所有订阅了成交通道的渠道
+func (m *MultiNotifier) NotifyOrderFill(fill Fill) error {
+	var errs []error
+	for _, ch := range m.channels {
+		if !ch.fills {
+			continue
+		}
+		if err := ch.notifier.NotifyOrderFill(fill); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+func joinErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d 个通知渠道推送失败: %v", len(errs), errs)
+}
+
+// BuyOnly 是一个常见的SignalFilter：只放行买入信号
+func BuyOnly(signal strategy.TradingSignal) bool {
+	return signal.Action == strategy.SignalBuy
+}
+
+// MinNotional 返回一个只放行名义价值不低于min的信号的SignalFilter
+func MinNotional(min float64) SignalFilter {
+	return func(signal strategy.TradingSignal) bool {
+		return signal.Price*signal.Quantity >= min
+	}
+}
+
+// AllSignals 放行所有信号
+func AllSignals(strategy.TradingSignal) bool {
+	return true
+}
+
+// combineFilters 返回一个只有当所有给定过滤条件都通过时才放行的SignalFilter
+func combineFilters(filters ...SignalFilter) SignalFilter {
+	return func(signal strategy.TradingSignal) bool {
+		for _, f := range filters {
+			if !f(signal) {
+				return false
+			}
+		}
+		return true
+	}
+}
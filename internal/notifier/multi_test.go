@@ -0,0 +1,79 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+type recordingNotifier struct {
+	signals []strategy.TradingSignal
+	errs    []error
+	fills   []Fill
+}
+
+func (r *recordingNotifier) NotifySignal(signal strategy.TradingSignal) error {
+	r.signals = append(r.signals, signal)
+	return nil
+}
+
+func (r *recordingNotifier) NotifyError(err error, fields map[string]any) error {
+	r.errs = append(r.errs, err)
+	return nil
+}
+
+func (r *recordingNotifier) NotifyOrderFill(fill Fill) error {
+	r.fills = append(r.fills, fill)
+	return nil
+}
+
+func TestMultiNotifierFiltersPerChannel(t *testing.T) {
+	buyChannel := &recordingNotifier{}
+	allChannel := &recordingNotifier{}
+
+	m := NewMultiNotifier()
+	m.AddChannel(buyChannel, BuyOnly, false, false)
+	m.AddChannel(allChannel, AllSignals, true, true)
+
+	buy := strategy.TradingSignal{Action: strategy.SignalBuy, Symbol: "BTCUSDT"}
+	sell := strategy.TradingSignal{Action: strategy.SignalSell, Symbol: "BTCUSDT"}
+
+	if err := m.NotifySignal(buy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.NotifySignal(sell); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(buyChannel.signals) != 1 {
+		t.Fatalf("buy-only channel应只收到1条信号，得到 %d", len(buyChannel.signals))
+	}
+	if len(allChannel.signals) != 2 {
+		t.Fatalf("全量channel应收到2条信号，得到 %d", len(allChannel.signals))
+	}
+
+	if err := m.NotifyError(errors.New("boom"), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(buyChannel.errs) != 0 {
+		t.Fatalf("未订阅错误通道的渠道不应收到错误")
+	}
+	if len(allChannel.errs) != 1 {
+		t.Fatalf("订阅了错误通道的渠道应收到1条错误")
+	}
+}
+
+func TestCombineFilters(t *testing.T) {
+	f := combineFilters(BuyOnly, MinNotional(100))
+
+	if f(strategy.TradingSignal{Action: strategy.SignalBuy, Price: 10, Quantity: 5}) {
+		t.Fatalf("名义价值不足时应被过滤")
+	}
+	if !f(strategy.TradingSignal{Action: strategy.SignalBuy, Price: 10, Quantity: 11}) {
+		t.Fatalf("满足所有条件时应放行")
+	}
+	if f(strategy.TradingSignal{Action: strategy.SignalSell, Price: 10, Quantity: 20}) {
+		t.Fatalf("非买入信号应被过滤")
+	}
+}
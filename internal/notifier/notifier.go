@@ -0,0 +1,31 @@
+// Package notifier 提供可插拔的交易信号/系统告警通知能力，支持多渠道
+// （飞书/钉钉/Slack/通用Webhook）依次扇出
+package notifier
+
+import (
+	"time"
+
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// Fill 订单成交回报
+type Fill struct {
+	OrderID   string    `json:"order_id"`
+	Symbol    string    `json:"symbol"`
+	Side      string    `json:"side"`
+	Price     float64   `json:"price"`
+	Quantity  float64   `json:"quantity"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notifier 是所有通知渠道必须实现的接口
+type Notifier interface {
+	// NotifySignal 推送一条交易信号
+	NotifySignal(signal strategy.TradingSignal) error
+
+	// NotifyError 推送一个系统错误，fields为附加上下文
+	NotifyError(err error, fields map[string]any) error
+
+	// NotifyOrderFill 推送一条订单成交回报
+	NotifyOrderFill(fill Fill) error
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// slackPayloadBuilder 生成Slack Incoming Webhook要求的负载
+type slackPayloadBuilder struct{}
+
+func (slackPayloadBuilder) SignalPayload(signal strategy.TradingSignal) any {
+	return map[string]any{
+		"text": fmt.Sprintf(":chart_with_upwards_trend: *%s* %s @ %.8f (策略: %s)",
+			signal.Action, signal.Symbol, signal.Price, signal.StrategyID),
+	}
+}
+
+func (slackPayloadBuilder) ErrorPayload(err error, fields map[string]any) any {
+	return map[string]any{
+		"text": fmt.Sprintf(":rotating_light: %v (上下文: %v)", err, fields),
+	}
+}
+
+func (slackPayloadBuilder) FillPayload(fill Fill) any {
+	return map[string]any{
+		"text": fmt.Sprintf(":moneybag: %s %s %.8f @ %.8f", fill.Side, fill.Symbol, fill.Quantity, fill.Price),
+	}
+}
+
+// NewSlackNotifier 创建Slack Incoming Webhook通知器
+func NewSlackNotifier(webhookURL string) *WebhookNotifier {
+	return NewWebhookNotifier("slack", webhookURL, slackPayloadBuilder{})
+}
+
+// genericPayloadBuilder 为不认识特定渠道格式的通用HTTP Webhook提供原始JSON负载
+type genericPayloadBuilder struct{}
+
+func (genericPayloadBuilder) SignalPayload(signal strategy.TradingSignal) any {
+	return map[string]any{"type": "signal", "signal": signal}
+}
+
+func (genericPayloadBuilder) ErrorPayload(err error, fields map[string]any) any {
+	return map[string]any{"type": "error", "message": err.Error(), "fields": fields}
+}
+
+func (genericPayloadBuilder) FillPayload(fill Fill) any {
+	return map[string]any{"type": "fill", "fill": fill}
+}
+
+// NewGenericWebhookNotifier 创建使用原始JSON负载的通用HTTP Webhook通知器
+func NewGenericWebhookNotifier(webhookURL string) *WebhookNotifier {
+	return NewWebhookNotifier("webhook", webhookURL, genericPayloadBuilder{})
+}
@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/finovatex/finovatex/internal/strategy"
+)
+
+// PayloadBuilder 把通用事件编译为某个Webhook渠道要求的请求体
+type PayloadBuilder interface {
+	SignalPayload(signal strategy.TradingSignal) any
+	ErrorPayload(err error, fields map[string]any) any
+	FillPayload(fill Fill) any
+}
+
+// WebhookNotifier 是所有基于HTTP Webhook的通知渠道的通用实现，具体的请求体
+// 格式由 PayloadBuilder 决定
+type WebhookNotifier struct {
+	Name    string
+	URL     string
+	Builder PayloadBuilder
+	client  *http.Client
+}
+
+// NewWebhookNotifier 创建一个通用Webhook通知器
+func NewWebhookNotifier(name, url string, builder PayloadBuilder) *WebhookNotifier {
+	return &WebhookNotifier{
+		Name:    name,
+		URL:     url,
+		Builder: builder,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// NotifySignal 推送交易信号
+func (w *WebhookNotifier) NotifySignal(signal strategy.TradingSignal) error {
+	return w.post(w.Builder.SignalPayload(signal))
+}
+
+// NotifyError 推送系统错误
+func (w *WebhookNotifier) NotifyError(err error, fields map[string]any) error {
+	return w.post(w.Builder.ErrorPayload(err, fields))
+}
+
+// NotifyOrderFill 推送订单成交回报
+func (w *WebhookNotifier) NotifyOrderFill(fill Fill) error {
+	return w.post(w.Builder.FillPayload(fill))
+}
+
+func (w *WebhookNotifier) post(payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化通知负载失败: %w", err)
+	}
+
+	resp, err := w.client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("推送到 %s 失败: %w", maskURL(w.URL), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s 返回非成功状态码: %d", w.Name, resp.StatusCode)
+	}
+	return nil
+}
+
+// maskURL 遮蔽Webhook地址中的token部分，避免明文泄露到日志
+func maskURL(url string) string {
+	if len(url) <= 24 {
+		return "***"
+	}
+	return url[:20] + "...(masked)"
+}
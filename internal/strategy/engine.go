@@ -0,0 +1,248 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/finovatex/finovatex/internal/exchange"
+)
+
+// symbolRuntime 是引擎为单个交易对维护的运行时状态
+type symbolRuntime struct {
+	strategy   Strategy
+	indicators *IndicatorSet
+}
+
+// dedupeBucket 是信号去重KV桶的名字；key永不过期，保证重启后也不会
+// 对同一根已收盘K线重复发出信号
+const dedupeBucket = "STRATEGY_SIGNAL_DEDUPE"
+
+// Engine 从JetStream消费行情、驱动已注册策略并发布去重后的交易信号
+type Engine struct {
+	js nats.JetStreamContext
+
+	mu      sync.Mutex
+	runtime map[string]*symbolRuntime
+
+	dedupe nats.KeyValue
+}
+
+// NewEngine 创建策略引擎，并绑定（或按需创建）信号去重KV桶；去重记录
+// 持久化在JetStream KV中，而不是进程内存，所以引擎重启后不会对同一根
+// 已收盘K线重复发出信号
+func NewEngine(js nats.JetStreamContext) (*Engine, error) {
+	kv, err := js.KeyValue(dedupeBucket)
+	if err != nil {
+		kv, err = js.CreateKeyValue(&nats.KeyValueConfig{Bucket: dedupeBucket})
+		if err != nil {
+			return nil, fmt.Errorf("创建信号去重KV桶 %s 失败: %w", dedupeBucket, err)
+		}
+	}
+
+	return &Engine{
+		js:      js,
+		runtime: make(map[string]*symbolRuntime),
+		dedupe:  kv,
+	}, nil
+}
+
+// LoadConfig 从YAML文件加载交易对/策略/参数配置
+func LoadConfig(path string) (*EngineConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取策略配置文件失败: %w", err)
+	}
+
+	var cfg EngineConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("解析策略配置文件失败: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Configure 根据配置为每个交易对实例化策略与指标集合
+func (e *Engine) Configure(cfg *EngineConfig) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, sc := range cfg.Symbols {
+		strat, err := BuildStrategy(sc.Strategy, sc.Params)
+		if err != nil {
+			return fmt.Errorf("为交易对 %s 构建策略失败: %w", sc.Symbol, err)
+		}
+		e.runtime[sc.Symbol] = &symbolRuntime{
+			strategy:   strat,
+			indicators: NewIndicatorSet(DefaultIndicatorConfig()),
+		}
+	}
+	return nil
+}
+
+// Run 启动对 market.prices.* 与 market.klines.* 的拉取消费，直到ctx被取消
+func (e *Engine) Run(ctx context.Context) error {
+	priceSub, err := e.js.PullSubscribe("market.prices.*", "strategy-engine-prices", nats.BindStream("MARKET_DATA"))
+	if err != nil {
+		return fmt.Errorf("订阅market.prices.*失败: %w", err)
+	}
+	defer priceSub.Unsubscribe()
+
+	klineSub, err := e.js.PullSubscribe("market.klines.*", "strategy-engine-klines", nats.BindStream("MARKET_DATA"))
+	if err != nil {
+		return fmt.Errorf("订阅market.klines.*失败: %w", err)
+	}
+	defer klineSub.Unsubscribe()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		e.consumeTrades(ctx, priceSub)
+	}()
+	go func() {
+		defer wg.Done()
+		e.consumeKlines(ctx, klineSub)
+	}()
+	wg.Wait()
+
+	return nil
+}
+
+func (e *Engine) consumeTrades(ctx context.Context, sub *nats.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		msgs, err := sub.Fetch(10, nats.Context(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		for _, msg := range msgs {
+			symbol := symbolFromSubject(msg.Subject)
+			var trade exchange.MarketData
+			if err := json.Unmarshal(msg.Data, &trade); err != nil {
+				log.Printf("解析成交消息失败: %v", err)
+				msg.Nak()
+				continue
+			}
+			e.dispatchTrade(symbol, trade)
+			msg.Ack()
+		}
+	}
+}
+
+func (e *Engine) consumeKlines(ctx context.Context, sub *nats.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		msgs, err := sub.Fetch(10, nats.Context(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		for _, msg := range msgs {
+			symbol := symbolFromSubject(msg.Subject)
+			var k exchange.Kline
+			if err := json.Unmarshal(msg.Data, &k); err != nil {
+				log.Printf("解析K线消息失败: %v", err)
+				msg.Nak()
+				continue
+			}
+			e.dispatchKline(symbol, k)
+			msg.Ack()
+		}
+	}
+}
+
+func symbolFromSubject(subject string) string {
+	parts := strings.Split(subject, ".")
+	return parts[len(parts)-1]
+}
+
+func (e *Engine) dispatchTrade(symbol string, trade exchange.MarketData) {
+	e.mu.Lock()
+	rt, ok := e.runtime[symbol]
+	e.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	signals, err := rt.strategy.OnTrade(symbol, trade)
+	if err != nil {
+		log.Printf("策略 %s 处理成交失败: %v", rt.strategy.ID(), err)
+		return
+	}
+	e.publishSignals(signals)
+}
+
+func (e *Engine) dispatchKline(symbol string, k exchange.Kline) {
+	e.mu.Lock()
+	rt, ok := e.runtime[symbol]
+	e.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	signals, err := rt.strategy.OnKline(symbol, k, rt.indicators)
+	if err != nil {
+		log.Printf("策略 %s 处理K线失败: %v", rt.strategy.ID(), err)
+		return
+	}
+	e.publishSignals(signals)
+}
+
+func (e *Engine) publishSignals(signals []TradingSignal) {
+	for _, sig := range signals {
+		if e.alreadySeen(sig) {
+			continue
+		}
+
+		data, err := json.Marshal(sig)
+		if err != nil {
+			log.Printf("序列化交易信号失败: %v", err)
+			continue
+		}
+
+		subject := fmt.Sprintf("signals.%s.%s", strings.ToLower(string(sig.Action)), sig.Symbol)
+		if _, err := e.js.Publish(subject, data); err != nil {
+			log.Printf("发布交易信号失败: %v", err)
+		}
+	}
+}
+
+// alreadySeen 实现信号幂等：同一个 StrategyID+Symbol+BarCloseTime 只发一次。
+// 去重记录保存在JetStream KV里（而不是进程内存里的map），所以引擎重启后
+// 依然能认出已经发过的信号，不会重复发出
+func (e *Engine) alreadySeen(sig TradingSignal) bool {
+	key := sig.dedupeKey()
+
+	if _, err := e.dedupe.Create(key, []byte("sent")); err != nil {
+		// key已存在，说明这条信号之前已经发过
+		return true
+	}
+	return false
+}
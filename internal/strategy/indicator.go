@@ -0,0 +1,207 @@
+package strategy
+
+import "math"
+
+// bar 是指标计算所需的最小OHLC信息
+type bar struct {
+	High  float64
+	Low   float64
+	Close float64
+}
+
+// IndicatorSet 为单个交易对维护一组滚动窗口指标
+type IndicatorSet struct {
+	maxWindow int
+	bars      []bar
+
+	smaPeriod  int
+	emaPeriod  int
+	bollPeriod int
+	bollStdDev float64
+	rsiPeriod  int
+	atrPeriod  int
+	nrPeriod   int
+
+	emaValue    float64
+	emaInit     bool
+	avgGain     float64
+	avgLoss     float64
+	rsiInit     bool
+}
+
+// IndicatorConfig 配置各指标的周期参数
+type IndicatorConfig struct {
+	SMAPeriod  int
+	EMAPeriod  int
+	BOLLPeriod int
+	BOLLStdDev float64
+	RSIPeriod  int
+	ATRPeriod  int
+	NRPeriod   int
+}
+
+// DefaultIndicatorConfig 返回常用默认周期
+func DefaultIndicatorConfig() IndicatorConfig {
+	return IndicatorConfig{
+		SMAPeriod:  20,
+		EMAPeriod:  20,
+		BOLLPeriod: 20,
+		BOLLStdDev: 2,
+		RSIPeriod:  14,
+		ATRPeriod:  14,
+		NRPeriod:   7,
+	}
+}
+
+// NewIndicatorSet 创建指标集合，窗口长度取所有周期中的最大值
+func NewIndicatorSet(cfg IndicatorConfig) *IndicatorSet {
+	maxWindow := cfg.SMAPeriod
+	for _, p := range []int{cfg.EMAPeriod, cfg.BOLLPeriod, cfg.RSIPeriod + 1, cfg.ATRPeriod + 1, cfg.NRPeriod} {
+		if p > maxWindow {
+			maxWindow = p
+		}
+	}
+
+	return &IndicatorSet{
+		maxWindow:  maxWindow,
+		smaPeriod:  cfg.SMAPeriod,
+		emaPeriod:  cfg.EMAPeriod,
+		bollPeriod: cfg.BOLLPeriod,
+		bollStdDev: cfg.BOLLStdDev,
+		rsiPeriod:  cfg.RSIPeriod,
+		atrPeriod:  cfg.ATRPeriod,
+		nrPeriod:   cfg.NRPeriod,
+	}
+}
+
+// Update 将一根新完成的K线纳入所有滚动窗口，并增量更新EMA/RSI状态
+func (s *IndicatorSet) Update(high, low, close float64) {
+	if len(s.bars) > 0 {
+		prevClose := s.bars[len(s.bars)-1].Close
+		s.updateRSI(close - prevClose)
+	}
+
+	s.bars = append(s.bars, bar{High: high, Low: low, Close: close})
+	if len(s.bars) > s.maxWindow {
+		s.bars = s.bars[len(s.bars)-s.maxWindow:]
+	}
+
+	s.updateEMA(close)
+}
+
+func (s *IndicatorSet) updateEMA(close float64) {
+	if !s.emaInit {
+		s.emaValue = close
+		s.emaInit = true
+		return
+	}
+	k := 2.0 / float64(s.emaPeriod+1)
+	s.emaValue = close*k + s.emaValue*(1-k)
+}
+
+func (s *IndicatorSet) updateRSI(delta float64) {
+	gain, loss := 0.0, 0.0
+	if delta > 0 {
+		gain = delta
+	} else {
+		loss = -delta
+	}
+
+	if !s.rsiInit {
+		s.avgGain = gain
+		s.avgLoss = loss
+		s.rsiInit = true
+		return
+	}
+
+	n := float64(s.rsiPeriod)
+	s.avgGain = (s.avgGain*(n-1) + gain) / n
+	s.avgLoss = (s.avgLoss*(n-1) + loss) / n
+}
+
+// SMA 返回简单移动平均，样本不足时返回 (0, false)
+func (s *IndicatorSet) SMA() (float64, bool) {
+	if len(s.bars) < s.smaPeriod {
+		return 0, false
+	}
+	window := s.bars[len(s.bars)-s.smaPeriod:]
+	sum := 0.0
+	for _, b := range window {
+		sum += b.Close
+	}
+	return sum / float64(s.smaPeriod), true
+}
+
+// EMA 返回指数移动平均
+func (s *IndicatorSet) EMA() (float64, bool) {
+	if len(s.bars) < s.emaPeriod {
+		return 0, false
+	}
+	return s.emaValue, true
+}
+
+// BOLL 返回布林带的中轨、上轨、下轨
+func (s *IndicatorSet) BOLL() (mid, upper, lower float64, ok bool) {
+	if len(s.bars) < s.bollPeriod {
+		return 0, 0, 0, false
+	}
+	window := s.bars[len(s.bars)-s.bollPeriod:]
+	sum := 0.0
+	for _, b := range window {
+		sum += b.Close
+	}
+	mean := sum / float64(s.bollPeriod)
+
+	variance := 0.0
+	for _, b := range window {
+		d := b.Close - mean
+		variance += d * d
+	}
+	stdDev := math.Sqrt(variance / float64(s.bollPeriod))
+
+	return mean, mean + s.bollStdDev*stdDev, mean - s.bollStdDev*stdDev, true
+}
+
+// RSI 返回相对强弱指数 (0-100)
+func (s *IndicatorSet) RSI() (float64, bool) {
+	if len(s.bars) <= s.rsiPeriod {
+		return 0, false
+	}
+	if s.avgLoss == 0 {
+		return 100, true
+	}
+	rs := s.avgGain / s.avgLoss
+	return 100 - (100 / (1 + rs)), true
+}
+
+// ATR 返回平均真实波幅
+func (s *IndicatorSet) ATR() (float64, bool) {
+	if len(s.bars) <= s.atrPeriod {
+		return 0, false
+	}
+	window := s.bars[len(s.bars)-s.atrPeriod-1:]
+	sum := 0.0
+	for i := 1; i < len(window); i++ {
+		curr, prev := window[i], window[i-1]
+		tr := math.Max(curr.High-curr.Low, math.Max(math.Abs(curr.High-prev.Close), math.Abs(curr.Low-prev.Close)))
+		sum += tr
+	}
+	return sum / float64(s.atrPeriod), true
+}
+
+// IsNarrowRange 判断最新一根K线的 high-low 是否为最近N根中最小的那根
+// (NR4/NR7)，常用于标记波动收缩、突破前夜
+func (s *IndicatorSet) IsNarrowRange() (bool, bool) {
+	if len(s.bars) < s.nrPeriod {
+		return false, false
+	}
+	window := s.bars[len(s.bars)-s.nrPeriod:]
+	latestRange := window[len(window)-1].High - window[len(window)-1].Low
+
+	for _, b := range window[:len(window)-1] {
+		if b.High-b.Low < latestRange {
+			return false, true
+		}
+	}
+	return true, true
+}
@@ -0,0 +1,61 @@
+package strategy
+
+import "testing"
+
+func TestIndicatorSetSMA(t *testing.T) {
+	cfg := DefaultIndicatorConfig()
+	cfg.SMAPeriod = 3
+	ind := NewIndicatorSet(cfg)
+
+	if _, ok := ind.SMA(); ok {
+		t.Fatalf("样本不足时SMA不应可用")
+	}
+
+	ind.Update(10, 9, 10)
+	ind.Update(11, 10, 11)
+	ind.Update(12, 11, 12)
+
+	sma, ok := ind.SMA()
+	if !ok {
+		t.Fatalf("样本足够后SMA应可用")
+	}
+	if sma != 11 {
+		t.Fatalf("期望SMA为11，得到 %v", sma)
+	}
+}
+
+func TestIndicatorSetNarrowRange(t *testing.T) {
+	cfg := DefaultIndicatorConfig()
+	cfg.NRPeriod = 3
+	ind := NewIndicatorSet(cfg)
+
+	ind.Update(110, 90, 100) // range 20
+	ind.Update(108, 95, 101) // range 13
+	ind.Update(103, 99, 102) // range 4 - smallest of last 3
+
+	isNR, ok := ind.IsNarrowRange()
+	if !ok {
+		t.Fatalf("样本足够后NR应可用")
+	}
+	if !isNR {
+		t.Fatalf("最新K线应被标记为窄幅整理")
+	}
+}
+
+func TestIndicatorSetNarrowRangeFalse(t *testing.T) {
+	cfg := DefaultIndicatorConfig()
+	cfg.NRPeriod = 3
+	ind := NewIndicatorSet(cfg)
+
+	ind.Update(103, 99, 102)  // range 4
+	ind.Update(108, 95, 101)  // range 13
+	ind.Update(130, 90, 120) // range 40 - not the smallest
+
+	isNR, ok := ind.IsNarrowRange()
+	if !ok {
+		t.Fatalf("样本足够后NR应可用")
+	}
+	if isNR {
+		t.Fatalf("最新K线不应被标记为窄幅整理")
+	}
+}
@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/finovatex/finovatex/internal/exchange"
+)
+
+// NRBreakoutStrategy 实现基于Narrow-Range（窄幅整理）的突破策略：当最新K线
+// 被标记为NR（波动收缩）后，一旦下一根K线向上突破前高则发出买入信号
+type NRBreakoutStrategy struct {
+	id       string
+	lastNR   map[string]bool
+	lastHigh map[string]float64
+}
+
+// NewNRBreakoutStrategy 创建NR突破策略
+func NewNRBreakoutStrategy(id string) *NRBreakoutStrategy {
+	return &NRBreakoutStrategy{
+		id:       id,
+		lastNR:   make(map[string]bool),
+		lastHigh: make(map[string]float64),
+	}
+}
+
+func init() {
+	RegisterStrategy("nr_breakout", func(params map[string]interface{}) (Strategy, error) {
+		id := "nr_breakout"
+		if v, ok := params["id"].(string); ok && v != "" {
+			id = v
+		}
+		return NewNRBreakoutStrategy(id), nil
+	})
+}
+
+// ID 返回策略标识
+func (s *NRBreakoutStrategy) ID() string {
+	return s.id
+}
+
+// OnKline 在窄幅整理后出现向上突破时产出买入信号
+func (s *NRBreakoutStrategy) OnKline(symbol string, k exchange.Kline, indicators *IndicatorSet) ([]TradingSignal, error) {
+	if !k.Closed {
+		return nil, nil
+	}
+
+	indicators.Update(k.High, k.Low, k.Close)
+
+	var signals []TradingSignal
+	if s.lastNR[symbol] && k.Close > s.lastHigh[symbol] {
+		signals = append(signals, TradingSignal{
+			StrategyID:   s.id,
+			Symbol:       symbol,
+			Action:       SignalBuy,
+			Price:        k.Close,
+			BarCloseTime: k.CloseTime,
+			Timestamp:    time.Now(),
+			Reason:       fmt.Sprintf("NR整理后突破前高 %.8f", s.lastHigh[symbol]),
+		})
+	}
+
+	isNR, ok := indicators.IsNarrowRange()
+	s.lastNR[symbol] = ok && isNR
+	s.lastHigh[symbol] = k.High
+
+	return signals, nil
+}
+
+// OnTrade NR突破策略只基于K线运作，逐笔成交不产生信号
+func (s *NRBreakoutStrategy) OnTrade(symbol string, t exchange.MarketData) ([]TradingSignal, error) {
+	return nil, nil
+}
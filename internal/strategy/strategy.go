@@ -0,0 +1,46 @@
+package strategy
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/finovatex/finovatex/internal/exchange"
+)
+
+// Strategy 是用户可注册的交易策略接口
+type Strategy interface {
+	// ID 返回策略标识，写入信号的 StrategyID 字段用于幂等去重
+	ID() string
+
+	// OnKline 在某个交易对收到一根新K线时调用
+	OnKline(symbol string, k exchange.Kline, indicators *IndicatorSet) ([]TradingSignal, error)
+
+	// OnTrade 在某个交易对收到一笔新成交时调用
+	OnTrade(symbol string, t exchange.MarketData) ([]TradingSignal, error)
+}
+
+// Builder 按YAML中的params构造一个策略实例
+type Builder func(params map[string]interface{}) (Strategy, error)
+
+var (
+	builderMu sync.RWMutex
+	builders  = make(map[string]Builder)
+)
+
+// RegisterStrategy 注册一个可通过YAML配置按名称引用的策略构造器
+func RegisterStrategy(name string, builder Builder) {
+	builderMu.Lock()
+	defer builderMu.Unlock()
+	builders[name] = builder
+}
+
+// BuildStrategy 按名称构造一个已注册的策略
+func BuildStrategy(name string, params map[string]interface{}) (Strategy, error) {
+	builderMu.RLock()
+	builder, ok := builders[name]
+	builderMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("未注册的策略: %s", name)
+	}
+	return builder(params)
+}
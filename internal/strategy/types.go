@@ -0,0 +1,50 @@
+// Package strategy 提供基于指标的可插拔策略引擎，消费 JetStream 上的行情
+// 并产出交易信号
+package strategy
+
+import (
+	"strings"
+	"time"
+)
+
+// SignalAction 信号动作
+type SignalAction string
+
+const (
+	SignalBuy  SignalAction = "BUY"
+	SignalSell SignalAction = "SELL"
+	SignalHold SignalAction = "HOLD"
+)
+
+// TradingSignal 策略引擎产出的交易信号
+type TradingSignal struct {
+	StrategyID   string       `json:"strategy_id"`
+	Symbol       string       `json:"symbol"`
+	Action       SignalAction `json:"action"`
+	Price        float64      `json:"price"`
+	Quantity     float64      `json:"quantity"`
+	BarCloseTime time.Time    `json:"bar_close_time"`
+	Timestamp    time.Time    `json:"timestamp"`
+	Reason       string       `json:"reason,omitempty"`
+}
+
+// dedupeKey 是信号幂等去重的复合键：StrategyID+Symbol+BarCloseTime，
+// 用"."分隔并把时间戳里的":"替换成"-"，使其满足JetStream KV的key合法
+// 字符集（[-/_=.a-zA-Z0-9]），可以直接用作去重KV桶的key
+func (s TradingSignal) dedupeKey() string {
+	ts := strings.ReplaceAll(s.BarCloseTime.UTC().Format(time.RFC3339Nano), ":", "-")
+	return s.StrategyID + "." + s.Symbol + "." + ts
+}
+
+// SymbolConfig 描述引擎为某个交易对加载的策略参数
+type SymbolConfig struct {
+	Symbol    string                 `yaml:"symbol"`
+	Timeframe string                 `yaml:"timeframe"`
+	Strategy  string                 `yaml:"strategy"`
+	Params    map[string]interface{} `yaml:"params"`
+}
+
+// EngineConfig 是 config/*.yaml 中策略引擎配置的根结构
+type EngineConfig struct {
+	Symbols []SymbolConfig `yaml:"symbols"`
+}
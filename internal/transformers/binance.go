@@ -0,0 +1,53 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+)
+
+// binanceTicker 是Binance行情data对象中我们关心的字段；payload是扁平的
+// data对象本身，不带combined-stream的{"stream","data"}外层包装
+type binanceTicker struct {
+	Symbol    string `json:"s"`
+	Price     string `json:"p"`
+	Volume    string `json:"v"`
+	EventTime int64  `json:"E"`
+}
+
+// BinanceTransformer 把Binance原始ticker payload归一化
+type BinanceTransformer struct{}
+
+func (BinanceTransformer) Exchange() string {
+	return "binance"
+}
+
+func (BinanceTransformer) Transform(msg *nats.Msg) ([]NormalizedTick, error) {
+	var raw binanceTicker
+	if err := json.Unmarshal(msg.Data, &raw); err != nil {
+		return nil, fmt.Errorf("解析Binance payload失败: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(raw.Price, 64)
+	if err != nil {
+		return nil, fmt.Errorf("解析Binance价格失败: %w", err)
+	}
+	volume, err := strconv.ParseFloat(raw.Volume, 64)
+	if err != nil {
+		return nil, fmt.Errorf("解析Binance成交量失败: %w", err)
+	}
+
+	ts := msToTime(raw.EventTime)
+	return []NormalizedTick{
+		{
+			Symbol: raw.Symbol, BaseUnit: "quote", Value: price,
+			Time: ts, UpdateTime: ts, Publisher: "binance", Protocol: "websocket",
+		},
+		{
+			Symbol: raw.Symbol, BaseUnit: "base", Value: volume,
+			Time: ts, UpdateTime: ts, Publisher: "binance", Protocol: "websocket",
+		},
+	}, nil
+}
@@ -0,0 +1,56 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// bybitTicker 是Bybit ticker payload中我们关心的字段；反向合约(如BTCUSD)
+// 上报的volume24h单位是satoshi，需要换算为BTC
+type bybitTicker struct {
+	Symbol    string  `json:"symbol"`
+	LastPrice float64 `json:"lastPrice,string"`
+	Volume24h float64 `json:"volume24h,string"`
+	Ts        int64   `json:"ts"`
+}
+
+// BybitTransformer 把Bybit原始ticker payload归一化
+type BybitTransformer struct{}
+
+func (BybitTransformer) Exchange() string {
+	return "bybit"
+}
+
+func (BybitTransformer) Transform(msg *nats.Msg) ([]NormalizedTick, error) {
+	var raw bybitTicker
+	if err := json.Unmarshal(msg.Data, &raw); err != nil {
+		return nil, fmt.Errorf("解析Bybit payload失败: %w", err)
+	}
+
+	ts := msToTime(raw.Ts)
+	volume := raw.Volume24h
+	volumeUnit := "base"
+	if isInverseContract(raw.Symbol) {
+		volume = satoshiToBTC(volume)
+		volumeUnit = "BTC"
+	}
+
+	return []NormalizedTick{
+		{
+			Symbol: raw.Symbol, BaseUnit: "quote", Value: raw.LastPrice,
+			Time: ts, UpdateTime: ts, Publisher: "bybit", Protocol: "websocket",
+		},
+		{
+			Symbol: raw.Symbol, BaseUnit: volumeUnit, Value: volume,
+			Time: ts, UpdateTime: ts, Publisher: "bybit", Protocol: "websocket",
+		},
+	}, nil
+}
+
+// isInverseContract 判断交易对是否为Bybit反向合约（以USD而非USDT计价）
+func isInverseContract(symbol string) bool {
+	return strings.HasSuffix(symbol, "USD")
+}
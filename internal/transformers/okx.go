@@ -0,0 +1,60 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// okxTicker 是OKX ticker频道payload中我们关心的字段
+type okxTicker struct {
+	InstID string `json:"instId"`
+	Last   string `json:"last"`
+	Vol24h string `json:"vol24h"`
+	Ts     string `json:"ts"`
+}
+
+// OKXTransformer 把OKX原始ticker payload归一化
+type OKXTransformer struct{}
+
+func (OKXTransformer) Exchange() string {
+	return "okx"
+}
+
+func (OKXTransformer) Transform(msg *nats.Msg) ([]NormalizedTick, error) {
+	var raw okxTicker
+	if err := json.Unmarshal(msg.Data, &raw); err != nil {
+		return nil, fmt.Errorf("解析OKX payload失败: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(raw.Last, 64)
+	if err != nil {
+		return nil, fmt.Errorf("解析OKX价格失败: %w", err)
+	}
+	volume, err := strconv.ParseFloat(raw.Vol24h, 64)
+	if err != nil {
+		return nil, fmt.Errorf("解析OKX成交量失败: %w", err)
+	}
+
+	tsMs, err := strconv.ParseInt(raw.Ts, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("解析OKX时间戳失败: %w", err)
+	}
+
+	ts := msToTime(tsMs)
+	// OKX的instId格式为 BTC-USDT，归一化为与Binance/Bybit一致的无连字符格式
+	symbol := strings.ReplaceAll(raw.InstID, "-", "")
+	return []NormalizedTick{
+		{
+			Symbol: symbol, BaseUnit: "quote", Value: price,
+			Time: ts, UpdateTime: ts, Publisher: "okx", Protocol: "websocket",
+		},
+		{
+			Symbol: symbol, BaseUnit: "base", Value: volume,
+			Time: ts, UpdateTime: ts, Publisher: "okx", Protocol: "websocket",
+		},
+	}, nil
+}
@@ -0,0 +1,50 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Pipeline 把一个原始行情主题喂给一组Transformer，并把每个Transformer
+// 产出的归一化记录republish到 finovatex.market.normalized.<exchange>.<symbol>
+type Pipeline struct {
+	js           nats.JetStreamContext
+	transformers []Transformer
+}
+
+// NewPipeline 创建一条按顺序依次应用给定Transformer的中间件链
+func NewPipeline(js nats.JetStreamContext, transformers ...Transformer) *Pipeline {
+	return &Pipeline{js: js, transformers: transformers}
+}
+
+// Handle 是可直接传给 nats.Subscribe/PullSubscribe 回调的消息处理器：单条
+// 原始消息可以fan out为N条归一化记录，分别发布到各自的交易对主题
+func (p *Pipeline) Handle(msg *nats.Msg) {
+	for _, tf := range p.transformers {
+		ticks, err := tf.Transform(msg)
+		if err != nil {
+			log.Printf("转换器 %s 处理消息失败: %v", tf.Exchange(), err)
+			continue
+		}
+		for _, tick := range ticks {
+			if err := p.publish(tf.Exchange(), tick); err != nil {
+				log.Printf("发布归一化记录失败: %v", err)
+			}
+		}
+	}
+}
+
+func (p *Pipeline) publish(exchange string, tick NormalizedTick) error {
+	subject := fmt.Sprintf("finovatex.market.normalized.%s.%s", exchange, tick.Symbol)
+	data, err := json.Marshal(tick)
+	if err != nil {
+		return fmt.Errorf("序列化归一化记录失败: %w", err)
+	}
+	if _, err := p.js.Publish(subject, data); err != nil {
+		return fmt.Errorf("发布到 %s 失败: %w", subject, err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+// Package transformers 把各交易所原始行情payload归一化为统一的
+// NormalizedTick记录，再重新发布到 finovatex.market.normalized.* 主题
+package transformers
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NormalizedTick 是SenML风格的归一化行情记录：name/unit/value/time
+type NormalizedTick struct {
+	Symbol     string    `json:"symbol"`
+	BaseUnit   string    `json:"base_unit"`
+	Value      float64   `json:"value"`
+	Time       time.Time `json:"time"`
+	UpdateTime time.Time `json:"update_time"`
+	Publisher  string    `json:"publisher"`
+	Protocol   string    `json:"protocol"`
+}
+
+// Transformer 把某个交易所的原始NATS消息转换为0到多条归一化记录
+type Transformer interface {
+	// Exchange 返回交易所标识，用于构造republish主题
+	Exchange() string
+	// Transform 解析msg的原始payload，产出归一化记录
+	Transform(msg *nats.Msg) ([]NormalizedTick, error)
+}
@@ -0,0 +1,50 @@
+package transformers
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestBinanceTransformerNormalizesPriceAndVolume(t *testing.T) {
+	msg := &nats.Msg{Data: []byte(`{"s":"BTCUSDT","p":"45000.12","v":"1.5","E":1700000000000}`)}
+
+	ticks, err := BinanceTransformer{}.Transform(msg)
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	if len(ticks) != 2 {
+		t.Fatalf("期望2条归一化记录，得到%d条", len(ticks))
+	}
+	if ticks[0].Value != 45000.12 || ticks[0].BaseUnit != "quote" {
+		t.Fatalf("价格记录不正确: %+v", ticks[0])
+	}
+	if ticks[1].Value != 1.5 || ticks[1].BaseUnit != "base" {
+		t.Fatalf("成交量记录不正确: %+v", ticks[1])
+	}
+}
+
+func TestOKXTransformerNormalizesInstID(t *testing.T) {
+	msg := &nats.Msg{Data: []byte(`{"instId":"BTC-USDT","last":"45000.12","vol24h":"1.5","ts":"1700000000000"}`)}
+
+	ticks, err := OKXTransformer{}.Transform(msg)
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	if ticks[0].Symbol != "BTCUSDT" {
+		t.Fatalf("期望symbol为BTCUSDT，得到%s", ticks[0].Symbol)
+	}
+}
+
+func TestBybitTransformerConvertsSatoshiForInverseContracts(t *testing.T) {
+	msg := &nats.Msg{Data: []byte(`{"symbol":"BTCUSD","lastPrice":"45000.12","volume24h":"100000000","ts":1700000000000}`)}
+
+	ticks, err := BybitTransformer{}.Transform(msg)
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	volumeTick := ticks[1]
+	if volumeTick.BaseUnit != "BTC" || volumeTick.Value != 1 {
+		t.Fatalf("反向合约成交量应换算为1 BTC，得到%+v", volumeTick)
+	}
+}
@@ -0,0 +1,15 @@
+package transformers
+
+import "time"
+
+// msToTime 把毫秒时间戳（各交易所行情payload的通行格式）转换为ns精度的
+// time.Time，统一归一化记录的时间单位
+func msToTime(ms int64) time.Time {
+	return time.UnixMilli(ms)
+}
+
+// satoshiToBTC 把以聪（satoshi）计的数量换算为BTC，用于部分反向合约交易所
+// 上报的以聪为单位的成交量字段
+func satoshiToBTC(satoshi float64) float64 {
+	return satoshi / 1e8
+}
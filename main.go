@@ -7,13 +7,28 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/finovatex/finovatex/internal/auth"
+	"github.com/finovatex/finovatex/internal/database"
+	"github.com/finovatex/finovatex/internal/exchange"
+	"github.com/finovatex/finovatex/internal/notifier"
+	"github.com/finovatex/finovatex/internal/strategy"
+	"github.com/finovatex/finovatex/pkg/messaging"
 )
 
+// bus是进程内唯一的消息总线实例：行情接入、策略引擎、历史行情写入等
+// 所有需要发布/订阅JetStream的子系统都共享这一个连接，而不是各自另开
+// 一条ad-hoc的nats.Connect，这样重连后的自动恢复逻辑（见pkg/messaging）
+// 只需要在一个地方处理
+var bus *messaging.Bus
+
 func main() {
 	// 设置Gin模式
 	if os.Getenv("GIN_MODE") == "" {
@@ -37,8 +52,33 @@ func main() {
 	// Prometheus指标端点
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
-	// API路由组
+	// 消息总线：行情接入/策略引擎/历史行情写入共享的唯一NATS连接
+	bus = loadBus()
+
+	// bgCtx贯穿行情接入/策略引擎等后台goroutine的整个生命周期，随进程
+	// 优雅关闭一起取消
+	bgCtx, bgCancel := context.WithCancel(context.Background())
+	defer bgCancel()
+
+	if bus != nil {
+		startMarketDataIngestion(bgCtx)
+		startStrategyEngine(bgCtx)
+		startMarketDataPersistence(bgCtx)
+	}
+
+	// 错误告警：把logrus.Error及以上级别的日志自动推送到配置好的通知渠道
+	startErrorNotifications()
+
+	// 鉴权：签发JWT访问令牌、轮换/吊销刷新令牌，登录和刷新接口本身保持公开
+	jwtSecret := []byte(getEnvOrDefault("FINOVATEX_JWT_SECRET", "dev-secret-change-me"))
+	tokenIssuer := auth.NewTokenIssuer(jwtSecret, "finovatex")
+	if authService := loadAuthService(tokenIssuer); authService != nil {
+		auth.RegisterRoutes(router.Group(""), authService)
+	}
+
+	// API路由组：除登录/刷新/登出、/health、/metrics外，其余接口都要求有效的JWT
 	v1 := router.Group("/api/v1")
+	v1.Use(auth.RequireAuth(tokenIssuer))
 	v1.GET("/status", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "FinovateX API is running",
@@ -46,6 +86,17 @@ func main() {
 		})
 	})
 
+	// 交易所连接器相关端点
+	v1.GET("/exchanges", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"connectors": exchange.Registered(),
+		})
+	})
+	v1.GET("/exchanges/:connector/contracts/:symbol", handleGetContractInfo)
+
+	// 历史行情查询端点，供回测与前端图表拉取K线
+	v1.GET("/market/klines", handleQueryKlines)
+
 	// 创建HTTP服务器
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -84,5 +135,263 @@ func main() {
 		return
 	}
 
+	if bus != nil {
+		if err := bus.Shutdown(); err != nil {
+			log.Printf("关闭消息总线失败: %v", err)
+		}
+	}
+
 	fmt.Println("Server exited")
 }
+
+// getEnvOrDefault 获取环境变量，未设置时返回默认值
+func getEnvOrDefault(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+// loadBus 连接到共享消息总线；连接失败时记录日志并返回nil，使服务在
+// NATS不可用的环境下仍能以纯HTTP API的方式启动（行情接入/策略引擎/
+// 历史行情写入会相应不可用）
+func loadBus() *messaging.Bus {
+	url := getEnvOrDefault("FINOVATEX_NATS_URL", "nats://localhost:4222")
+	b, err := messaging.NewBus(url)
+	if err != nil {
+		log.Printf("连接消息总线失败，行情接入/策略引擎/历史行情写入将不可用: %v", err)
+		return nil
+	}
+	return b
+}
+
+// startMarketDataIngestion 构造配置的交易所连接器，并把其成交/深度流桥接
+// 到JetStream；连接器未配置或创建失败时只记录日志，不阻塞服务启动
+func startMarketDataIngestion(ctx context.Context) {
+	connectorName := getEnvOrDefault("FINOVATEX_EXCHANGE_CONNECTOR", "binance-spot")
+	symbols := strings.Split(getEnvOrDefault("FINOVATEX_EXCHANGE_SYMBOLS", "BTCUSDT,ETHUSDT"), ",")
+
+	conn, err := exchange.New(connectorName, exchange.Config{
+		APIKey:    os.Getenv("FINOVATEX_EXCHANGE_API_KEY"),
+		APISecret: os.Getenv("FINOVATEX_EXCHANGE_API_SECRET"),
+	})
+	if err != nil {
+		log.Printf("创建交易所连接器 %s 失败，行情接入将不可用: %v", connectorName, err)
+		return
+	}
+
+	if err := exchange.PublishMarketData(ctx, bus.JetStream(), conn, symbols); err != nil {
+		log.Printf("启动行情接入失败: %v", err)
+		conn.Close()
+		return
+	}
+
+	log.Printf("已启动行情接入: connector=%s symbols=%v", connectorName, symbols)
+}
+
+// startStrategyEngine 按配置文件构建策略引擎，并在后台goroutine中运行，
+// 直到ctx被取消；配置文件缺失或解析失败时只记录日志，不阻塞服务启动
+func startStrategyEngine(ctx context.Context) {
+	cfgPath := getEnvOrDefault("FINOVATEX_STRATEGY_CONFIG", "config/strategy.yaml")
+
+	cfg, err := strategy.LoadConfig(cfgPath)
+	if err != nil {
+		log.Printf("加载策略配置 %s 失败，策略引擎将不可用: %v", cfgPath, err)
+		return
+	}
+
+	engine, err := strategy.NewEngine(bus.JetStream())
+	if err != nil {
+		log.Printf("创建策略引擎失败: %v", err)
+		return
+	}
+	if err := engine.Configure(cfg); err != nil {
+		log.Printf("配置策略引擎失败: %v", err)
+		return
+	}
+
+	go func() {
+		if err := engine.Run(ctx); err != nil {
+			log.Printf("策略引擎退出: %v", err)
+		}
+	}()
+
+	log.Printf("已启动策略引擎: config=%s", cfgPath)
+}
+
+// startErrorNotifications 按配置文件装配通知渠道，并把它注册为logrus的
+// Hook：此后任何通过logrus记录的Error及以上级别日志都会自动推送到配置
+// 好的渠道。本仓库目前大部分代码仍使用标准库log而非logrus，迁移调用点
+// 不在本次修复范围内，这里只保证Hook本身真正被注册、不再是从未触达的
+// 死代码。配置文件缺失或解析失败时只记录日志，不阻塞服务启动
+func startErrorNotifications() {
+	cfgPath := getEnvOrDefault("FINOVATEX_NOTIFIER_CONFIG", "config/notifier.yaml")
+
+	cfg, err := notifier.LoadConfigFromFile(cfgPath)
+	if err != nil {
+		log.Printf("加载通知配置 %s 失败，错误告警将不可用: %v", cfgPath, err)
+		return
+	}
+
+	mn, err := notifier.BuildMultiNotifier(cfg)
+	if err != nil {
+		log.Printf("构建通知渠道失败，错误告警将不可用: %v", err)
+		return
+	}
+
+	logrus.AddHook(notifier.NewLogHook(mn))
+	log.Printf("已注册错误告警Hook: config=%s", cfgPath)
+}
+
+// startMarketDataPersistence 把 market.prices.*/market.klines.*/signals.*.*
+// 注册为消息总线的流watcher，将途经的每一笔成交、K线和交易信号写入
+// TimescaleDB，并启动TimescaleDB统计指标的Prometheus导出；数据库不可用
+// 时只记录日志，不阻塞服务启动
+func startMarketDataPersistence(ctx context.Context) {
+	cfg := database.LoadConfigFromEnv()
+	db, err := database.Connect(cfg)
+	if err != nil {
+		log.Printf("连接历史行情数据库失败，行情/信号持久化将不可用: %v", err)
+		return
+	}
+	store := database.NewMarketDataStore(db)
+
+	insert := func(do func(context.Context) error) {
+		ictx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if err := do(ictx); err != nil {
+			log.Printf("写入历史行情/信号失败: %v", err)
+		}
+	}
+
+	watchers := []messaging.NatsStreamWatcher{
+		{
+			Stream: "MARKET_DATA",
+			Topic:  "market.prices.*",
+			Entity: func() interface{} { return &exchange.MarketData{} },
+			Cb: func(_ string, entity interface{}) {
+				insert(func(ictx context.Context) error {
+					return store.InsertTick(ictx, *entity.(*exchange.MarketData))
+				})
+			},
+		},
+		{
+			Stream: "MARKET_DATA",
+			Topic:  "market.klines.*",
+			Entity: func() interface{} { return &exchange.Kline{} },
+			Cb: func(_ string, entity interface{}) {
+				insert(func(ictx context.Context) error {
+					return store.InsertKline1m(ictx, *entity.(*exchange.Kline))
+				})
+			},
+		},
+		{
+			Stream: "TRADING_SIGNALS",
+			Topic:  "signals.*.*",
+			Entity: func() interface{} { return &strategy.TradingSignal{} },
+			Cb: func(_ string, entity interface{}) {
+				insert(func(ictx context.Context) error {
+					return store.InsertSignal(ictx, *entity.(*strategy.TradingSignal))
+				})
+			},
+		},
+	}
+
+	for _, w := range watchers {
+		if err := bus.RegisterStreamWatcher(w); err != nil {
+			log.Printf("注册历史行情持久化watcher失败 (topic=%s): %v", w.Topic, err)
+		}
+	}
+
+	database.StartTimescaleStatsExporter(ctx, db, time.Minute)
+	log.Printf("已启动历史行情/信号持久化与TimescaleDB统计指标导出")
+}
+
+// loadAuthService 连接数据库并构建鉴权服务；连接失败时记录日志并返回nil，
+// 使服务在数据库不可用的环境下仍能以无鉴权接口的方式启动
+func loadAuthService(issuer *auth.TokenIssuer) *auth.Service {
+	cfg := database.LoadConfigFromEnv()
+	db, err := database.Connect(cfg)
+	if err != nil {
+		log.Printf("连接鉴权数据库失败，鉴权接口将不可用: %v", err)
+		return nil
+	}
+	return auth.NewService(auth.NewStore(db), issuer)
+}
+
+// marketDataStore 懒加载的历史行情存储，未配置数据库时保持为nil
+var marketDataStore *database.MarketDataStore
+
+func loadMarketDataStore() *database.MarketDataStore {
+	cfg := database.LoadConfigFromEnv()
+	db, err := database.Connect(cfg)
+	if err != nil {
+		log.Printf("连接历史行情数据库失败: %v", err)
+		return nil
+	}
+	return database.NewMarketDataStore(db)
+}
+
+// handleQueryKlines 查询某个交易对在指定周期/时间范围内的历史K线
+func handleQueryKlines(c *gin.Context) {
+	symbol := c.Query("symbol")
+	interval := c.Query("interval")
+	if symbol == "" || interval == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "symbol和interval为必填参数"})
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from必须是RFC3339格式的时间"})
+		return
+	}
+	to, err := time.Parse(time.RFC3339, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to必须是RFC3339格式的时间"})
+		return
+	}
+
+	if marketDataStore == nil {
+		marketDataStore = loadMarketDataStore()
+	}
+	if marketDataStore == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "历史行情数据库不可用"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	klines, err := marketDataStore.QueryKlines(ctx, symbol, interval, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"klines": klines})
+}
+
+// handleGetContractInfo 查询指定交易所连接器上某个交易对的合约精度信息
+func handleGetContractInfo(c *gin.Context) {
+	connectorName := c.Param("connector")
+	symbol := c.Param("symbol")
+
+	conn, err := exchange.New(connectorName, exchange.Config{})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	info, err := conn.GetContractInfo(ctx, symbol)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
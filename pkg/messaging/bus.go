@@ -0,0 +1,217 @@
+package messaging
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ConnState 描述 Bus 底层连接的状态迁移，通过 StateChanges() 广播给关心
+// 连接状态的调用方（例如用于告警或降级其它子系统）
+type ConnState string
+
+const (
+	ConnStateDisconnected ConnState = "disconnected"
+	ConnStateReconnected  ConnState = "reconnected"
+)
+
+// NatsStreamWatcher 描述一个需要在连接（重）建立后自动挂载的JetStream推送
+// 订阅（通过js.Subscribe/js.QueueSubscribe建立，不是拉取消费者）：Stream/Topic决定绑定哪个流和主题，Queue为空时每个Bus实例都会
+// 收到广播，非空时同一Queue下的多个worker负载均衡，Cb是收到消息后的回调
+type NatsStreamWatcher struct {
+	Stream        string
+	Topic         string
+	Queue         string
+	AckWaitMinute int
+	Entity        func() interface{}
+	Cb            func(subject string, entity interface{})
+}
+
+// Bus 持有唯一的 nats.Conn，并维护一份流watcher的注册表；重连后会重新
+// 调用注册表里的每一项，使调用方不需要自己处理流watcher断线重连后的
+// 重新订阅逻辑。注册表只包含流watcher：主题watcher和replyer不在其中，
+// Bus在重连后不会重建它们
+type Bus struct {
+	conn *nats.Conn
+	js   nats.JetStreamContext
+
+	mu       sync.Mutex
+	watchers []NatsStreamWatcher
+	subs     []*nats.Subscription
+
+	stateCh chan ConnState
+}
+
+// NewBus 连接到url并返回一个Bus；DisconnectedCB/ReconnectedCB被接好，
+// 重连后会自动重建所有已注册的流watcher
+func NewBus(url string, natsOpts ...nats.Option) (*Bus, error) {
+	b := &Bus{stateCh: make(chan ConnState, 16)}
+
+	opts := append([]nats.Option{
+		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
+			log.Printf("NATS连接断开: %v", err)
+			b.broadcastState(ConnStateDisconnected)
+		}),
+		nats.ReconnectHandler(func(nc *nats.Conn) {
+			log.Printf("NATS已重连到 %s", nc.ConnectedUrl())
+			b.broadcastState(ConnStateReconnected)
+			b.rebuildWatchers()
+		}),
+	}, natsOpts...)
+
+	conn, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("连接NATS失败: %w", err)
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("创建JetStream上下文失败: %w", err)
+	}
+
+	b.conn = conn
+	b.js = js
+	return b, nil
+}
+
+// StateChanges 返回一个只读channel，每次连接状态迁移都会收到一条记录
+func (b *Bus) StateChanges() <-chan ConnState {
+	return b.stateCh
+}
+
+func (b *Bus) broadcastState(state ConnState) {
+	select {
+	case b.stateCh <- state:
+	default:
+		// channel已满时丢弃，避免阻塞NATS的回调goroutine
+	}
+}
+
+// RegisterStreamWatcher 注册并立即启动一个流watcher；连接断开重连后会
+// 以同样的参数自动重新建立，调用方无需感知
+func (b *Bus) RegisterStreamWatcher(w NatsStreamWatcher) error {
+	b.mu.Lock()
+	b.watchers = append(b.watchers, w)
+	b.mu.Unlock()
+
+	return b.startStreamWatcherHandle(w)
+}
+
+func (b *Bus) startStreamWatcherHandle(w NatsStreamWatcher) error {
+	ackWait := time.Duration(w.AckWaitMinute) * time.Minute
+	if ackWait <= 0 {
+		ackWait = 30 * time.Second
+	}
+
+	var subOpts []nats.SubOpt
+	subOpts = append(subOpts, nats.BindStream(w.Stream), nats.AckWait(ackWait))
+
+	// Durable名称不能包含'.'、'*'、'>'，这里把Topic中的这些字符替换掉
+	durable := "watcher-" + strings.NewReplacer(".", "_", "*", "_", ">", "_").Replace(w.Topic)
+	var sub *nats.Subscription
+	var err error
+	if w.Queue != "" {
+		sub, err = b.js.QueueSubscribe(w.Topic, w.Queue, func(msg *nats.Msg) {
+			b.dispatchWatcher(w, msg)
+		}, subOpts...)
+	} else {
+		sub, err = b.js.Subscribe(w.Topic, func(msg *nats.Msg) {
+			b.dispatchWatcher(w, msg)
+		}, append(subOpts, nats.Durable(durable))...)
+	}
+	if err != nil {
+		return fmt.Errorf("建立流watcher失败 (stream=%s topic=%s): %w", w.Stream, w.Topic, err)
+	}
+
+	b.mu.Lock()
+	b.subs = append(b.subs, sub)
+	b.mu.Unlock()
+
+	return nil
+}
+
+func (b *Bus) dispatchWatcher(w NatsStreamWatcher, msg *nats.Msg) {
+	entity := w.Entity()
+	if err := (JSONCodec{}).Unmarshal(msg.Data, entity); err != nil {
+		log.Printf("解码流watcher消息失败 (topic=%s): %v", w.Topic, err)
+		msg.Nak()
+		return
+	}
+	w.Cb(msg.Subject, entity)
+	msg.Ack()
+}
+
+// rebuildWatchers 在重连后把已注册的每个watcher重新建立；旧的Subscription
+// 随断线已失效，这里只是替换掉b.subs中的记录
+func (b *Bus) rebuildWatchers() {
+	b.mu.Lock()
+	watchers := append([]NatsStreamWatcher(nil), b.watchers...)
+	b.subs = nil
+	b.mu.Unlock()
+
+	for _, w := range watchers {
+		if err := b.startStreamWatcherHandle(w); err != nil {
+			log.Printf("重连后重建流watcher失败: %v", err)
+		}
+	}
+}
+
+// Conn 返回底层的 nats.Conn，供需要直接访问的代码（如 pkg/natsrpc）使用
+func (b *Bus) Conn() *nats.Conn {
+	return b.conn
+}
+
+// JetStream 返回底层的 JetStreamContext
+func (b *Bus) JetStream() nats.JetStreamContext {
+	return b.js
+}
+
+// Shutdown 排空所有订阅、flush连接并关闭它；会阻塞直到所有watcher都已
+// 处理完已投递的消息并退出，最多等待5秒
+func (b *Bus) Shutdown() error {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = nil
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		if err := sub.Drain(); err != nil {
+			log.Printf("排空订阅失败: %v", err)
+		}
+	}
+
+	// Drain是异步的：排空完成后订阅变为无效，在此之前持续等待
+	deadline := time.Now().Add(5 * time.Second)
+	for _, sub := range subs {
+		for sub.IsValid() && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if err := b.conn.FlushTimeout(5 * time.Second); err != nil {
+		log.Printf("flush连接失败: %v", err)
+	}
+
+	b.conn.Close()
+	return nil
+}
+
+// ListenForShutdown 阻塞直到收到SIGINT/SIGTERM，然后优雅关闭Bus；适合
+// 作为服务main函数的收尾调用
+func (b *Bus) ListenForShutdown() {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("收到关闭信号，正在优雅关闭消息总线...")
+	if err := b.Shutdown(); err != nil {
+		log.Printf("关闭消息总线失败: %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+// Package messaging 在 nats.JetStreamContext 之上提供可插拔的编解码与
+// 发布/订阅封装，使消息在JSON与Protobuf之间可以互通
+package messaging
+
+// ContentTypeHeader 是消息头中标识编码格式的key，订阅端据此自动选择解码器
+const ContentTypeHeader = "Nats-Msg-Content-Type"
+
+// Codec 把消息体序列化/反序列化为线上字节，并声明对应的内容类型
+type Codec interface {
+	// Marshal 把v编码为字节
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal 把数据解码进v
+	Unmarshal(data []byte, v interface{}) error
+	// ContentType 返回写入 Nats-Msg-Content-Type 头的值
+	ContentType() string
+}
@@ -0,0 +1,102 @@
+package messaging
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsSubscription 把 *nats.Subscription 适配成 Subscription 接口
+type natsSubscription struct {
+	sub *nats.Subscription
+}
+
+func (s *natsSubscription) Unsubscribe() error {
+	return s.sub.Unsubscribe()
+}
+
+// Broadcast 用核心NATS（非JetStream）订阅subject，每个调用Broadcast的
+// 订阅者都会收到每一条消息，适合状态广播、缓存失效这类不需要持久化、
+// 也不需要负载均衡的场景
+func Broadcast(conn *nats.Conn, subject string, codec Codec, factory func() interface{}, handler Handler) (Subscription, error) {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	sub, err := conn.Subscribe(subject, func(msg *nats.Msg) {
+		v := factory()
+		if err := codec.Unmarshal(msg.Data, v); err != nil {
+			return
+		}
+		handler(subject, v)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("广播订阅 %s 失败: %w", subject, err)
+	}
+
+	return &natsSubscription{sub: sub}, nil
+}
+
+// QueueSubscribe 把一个worker绑定到JetStream的Durable consumer，并通过
+// group参数加入队列组；同一group下的多个worker会被JetStream在投递时负载
+// 均衡，每条消息只由其中一个worker处理，而不是像Broadcast那样人人都收到
+func QueueSubscribe(js nats.JetStreamContext, subject, durable, group string, codec Codec, factory func() interface{}, handler Handler) (Subscription, error) {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	sub, err := js.QueueSubscribe(subject, group, func(msg *nats.Msg) {
+		v := factory()
+		if err := codec.Unmarshal(msg.Data, v); err != nil {
+			msg.Nak()
+			return
+		}
+		if err := handler(subject, v); err != nil {
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}, nats.Durable(durable), nats.ManualAck())
+	if err != nil {
+		return nil, fmt.Errorf("建立队列组订阅失败 (subject=%s group=%s): %w", subject, group, err)
+	}
+
+	return &natsSubscription{sub: sub}, nil
+}
+
+// WorkerPool 在同一个队列组里启动size个worker共享负载；Stop会依次退订
+// 池中的所有worker
+type WorkerPool struct {
+	mu   sync.Mutex
+	subs []Subscription
+}
+
+// NewWorkerPool 创建并立即启动size个共享同一个Durable consumer和队列组的worker
+func NewWorkerPool(js nats.JetStreamContext, subject, durable, group string, size int, codec Codec, factory func() interface{}, handler Handler) (*WorkerPool, error) {
+	pool := &WorkerPool{}
+	for i := 0; i < size; i++ {
+		sub, err := QueueSubscribe(js, subject, durable, group, codec, factory, handler)
+		if err != nil {
+			pool.Stop()
+			return nil, fmt.Errorf("创建第%d个worker失败: %w", i, err)
+		}
+		pool.subs = append(pool.subs, sub)
+	}
+	return pool, nil
+}
+
+// Stop 退订池中所有worker；遇到的第一个错误会被返回，但仍会尝试退订其余worker
+func (p *WorkerPool) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var firstErr error
+	for _, sub := range p.subs {
+		if err := sub.Unsubscribe(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	p.subs = nil
+	return firstErr
+}
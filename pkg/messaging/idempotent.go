@@ -0,0 +1,175 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// PartitionKeyFunc 从一条已解码的消息中提取分区key（例如按symbol分流），
+// 同一分区key的消息会被同一个顺序worker串行处理，不同分区之间并发处理
+// 互不阻塞
+type PartitionKeyFunc func(v interface{}) string
+
+// partitionJob 是投递给某个分区worker的一条待处理消息，携带已解码好的值，
+// 避免在worker里重复解码
+type partitionJob struct {
+	msg *nats.Msg
+	v   interface{}
+}
+
+// IdempotentPubSub 在普通的JetStream发布/订阅之上叠加一层基于KV桶的幂等
+// 去重：发布时把调用方提供的去重key写入 Nats-Msg-Id 头（借助JetStream自
+// 带的短期去重窗口），消费时在KV桶中做一次原子Create为这个key“占位”，
+// 重复消息会在占位失败时被直接Ack掉而不会二次触发handler；订阅使用单个
+// OrderedConsumer拉取整个subject，再按partitionKeyFn把消息分发给每个
+// 分区各自的顺序worker，保证同一分区的消息严格按流内顺序处理；某个分区的
+// handler发生panic时会被恢复并Nak该消息，该分区worker继续运行，也不会
+// 影响其它分区
+type IdempotentPubSub struct {
+	js    nats.JetStreamContext
+	kv    nats.KeyValue
+	codec Codec
+
+	mu         sync.Mutex
+	partitions map[string]chan partitionJob
+}
+
+// NewIdempotentPubSub 绑定或创建一个TTL为ttl的KV桶，用于记录已处理过的
+// 去重key
+func NewIdempotentPubSub(js nats.JetStreamContext, bucket string, ttl time.Duration) (*IdempotentPubSub, error) {
+	kv, err := js.KeyValue(bucket)
+	if err != nil {
+		kv, err = js.CreateKeyValue(&nats.KeyValueConfig{Bucket: bucket, TTL: ttl})
+		if err != nil {
+			return nil, fmt.Errorf("创建幂等去重KV桶 %s 失败: %w", bucket, err)
+		}
+	}
+	return &IdempotentPubSub{js: js, kv: kv, codec: JSONCodec{}, partitions: map[string]chan partitionJob{}}, nil
+}
+
+// Publish 把v编码后发布到subject，并把key写入Nats-Msg-Id头；相同key在
+// JetStream的去重窗口内重复发布会被流直接拒收
+func (p *IdempotentPubSub) Publish(ctx context.Context, subject, key string, v interface{}) error {
+	data, err := p.codec.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("编码消息失败: %w", err)
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	msg.Header.Set(ContentTypeHeader, p.codec.ContentType())
+	msg.Header.Set(nats.MsgIdHdr, key)
+
+	if _, err := p.js.PublishMsg(msg, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("发布到 %s 失败: %w", subject, err)
+	}
+	return nil
+}
+
+// Subscribe 以单个OrderedConsumer拉取subject下的所有消息，按
+// partitionKeyFn算出的分区key把消息路由给各自的顺序worker处理；每条
+// 消息在交给handler前，先按Nats-Msg-Id头在KV桶里做一次原子占位，占位
+// 失败（key已存在）说明是重复消息，直接Ack跳过
+func (p *IdempotentPubSub) Subscribe(ctx context.Context, subject string, partitionKeyFn PartitionKeyFunc, factory func() interface{}, handler Handler) (Subscription, error) {
+	sub, err := p.js.Subscribe(subject, func(msg *nats.Msg) {
+		p.route(ctx, msg, subject, partitionKeyFn, factory, handler)
+	}, nats.OrderedConsumer(), nats.Context(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("订阅 %s 失败: %w", subject, err)
+	}
+	return &natsSubscription{sub: sub}, nil
+}
+
+// route 解码消息、算出分区key，再把消息投递到该分区专属的顺序worker；
+// 同一分区的消息由单个OrderedConsumer按流内顺序回调到这里，再按到达
+// 顺序压入分区channel，因此分区内处理顺序与流内顺序一致
+func (p *IdempotentPubSub) route(ctx context.Context, msg *nats.Msg, subject string, partitionKeyFn PartitionKeyFunc, factory func() interface{}, handler Handler) {
+	v := factory()
+	if err := p.codec.Unmarshal(msg.Data, v); err != nil {
+		msg.Nak()
+		return
+	}
+
+	key := ""
+	if partitionKeyFn != nil {
+		key = partitionKeyFn(v)
+	}
+
+	ch := p.partitionChan(ctx, key, subject, handler)
+	ch <- partitionJob{msg: msg, v: v}
+}
+
+func (p *IdempotentPubSub) partitionChan(ctx context.Context, partitionKey, subject string, handler Handler) chan partitionJob {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ch, ok := p.partitions[partitionKey]; ok {
+		return ch
+	}
+
+	ch := make(chan partitionJob, 64)
+	p.partitions[partitionKey] = ch
+	go p.runPartitionWorker(ctx, ch, subject, handler)
+	return ch
+}
+
+// runPartitionWorker 串行处理单个分区的消息；对handler的panic做恢复，
+// 避免一个分区里的一次异常处理导致整个worker退出、该分区从此停摆
+func (p *IdempotentPubSub) runPartitionWorker(ctx context.Context, ch chan partitionJob, subject string, handler Handler) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job, ok := <-ch:
+			if !ok {
+				return
+			}
+			p.processWithRecovery(job, subject, handler)
+		}
+	}
+}
+
+func (p *IdempotentPubSub) processWithRecovery(job partitionJob, subject string, handler Handler) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("幂等订阅处理消息时panic: %v", r)
+			job.msg.Nak()
+		}
+	}()
+	p.dispatch(job, subject, handler)
+}
+
+func (p *IdempotentPubSub) dispatch(job partitionJob, subject string, handler Handler) {
+	msg := job.msg
+	key := msg.Header.Get(nats.MsgIdHdr)
+	if key != "" {
+		if _, err := p.kv.Create(key, []byte("processing")); err != nil {
+			// key已存在，说明已经处理过，直接确认跳过
+			msg.Ack()
+			return
+		}
+	}
+
+	if err := handler(subject, job.v); err != nil {
+		p.releaseKey(key)
+		msg.Nak()
+		return
+	}
+
+	msg.Ack()
+}
+
+// releaseKey 在处理失败时删除占位的KV条目，使该消息在重试时可以再次抢占位
+func (p *IdempotentPubSub) releaseKey(key string) {
+	if key == "" {
+		return
+	}
+	if err := p.kv.Delete(key); err != nil {
+		fmt.Printf("释放幂等占位key %s 失败: %v\n", key, err)
+	}
+}
@@ -0,0 +1,21 @@
+package messaging
+
+import "encoding/json"
+
+// JSONContentType 是 JSONCodec 的内容类型
+const JSONContentType = "application/json"
+
+// JSONCodec 用 encoding/json 编解码，是所有现存发布者/订阅者的默认行为
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (JSONCodec) ContentType() string {
+	return JSONContentType
+}
@@ -0,0 +1,34 @@
+package messaging
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// ProtobufContentType 是 ProtobufCodec 的内容类型
+const ProtobufContentType = "application/x-protobuf"
+
+// ProtobufCodec 用Protobuf编解码，供需要与Python策略worker、Rust执行引擎
+// 等非Go消费者互通的主题使用；v必须实现 proto.Message
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("ProtobufCodec: %T 未实现 proto.Message", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (ProtobufCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("ProtobufCodec: %T 未实现 proto.Message", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func (ProtobufCodec) ContentType() string {
+	return ProtobufContentType
+}
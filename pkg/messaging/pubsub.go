@@ -0,0 +1,152 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Publisher 把一个值发布到某个主题
+type Publisher interface {
+	Publish(ctx context.Context, subject string, v interface{}) error
+}
+
+// Handler 处理一条已解码的消息；返回错误会导致消息被Nak而不是Ack
+type Handler func(subject string, v interface{}) error
+
+// Subscriber 订阅某个主题，每条消息用factory构造出的新实例承载解码结果
+type Subscriber interface {
+	Subscribe(ctx context.Context, subject, durable string, factory func() interface{}, handler Handler) (Subscription, error)
+}
+
+// Subscription 是一次订阅的句柄，调用Unsubscribe停止消费
+type Subscription interface {
+	Unsubscribe() error
+}
+
+// PubSub 同时实现 Publisher 与 Subscriber
+type PubSub interface {
+	Publisher
+	Subscriber
+}
+
+// jetStreamPubSub 把 nats.JetStreamContext 包装成按内容类型自动选择编解
+// 码器的 PubSub，取代了此前各处硬编码 json.Marshal/Unmarshal 的做法
+type jetStreamPubSub struct {
+	js            nats.JetStreamContext
+	defaultCodec  Codec
+	codecsByType  map[string]Codec
+	fetchBatch    int
+	fetchInterval time.Duration
+}
+
+// Option 配置 jetStreamPubSub
+type Option func(*jetStreamPubSub)
+
+// WithCodec 额外注册一个可被订阅端按内容类型识别的编解码器
+func WithCodec(codec Codec) Option {
+	return func(p *jetStreamPubSub) {
+		p.codecsByType[codec.ContentType()] = codec
+	}
+}
+
+// NewPubSub 创建一个默认使用JSON编码发布、同时能按 Nats-Msg-Content-Type
+// 头自动派发JSON/Protobuf解码的 PubSub
+func NewPubSub(js nats.JetStreamContext, opts ...Option) PubSub {
+	p := &jetStreamPubSub{
+		js:            js,
+		defaultCodec:  JSONCodec{},
+		codecsByType:  map[string]Codec{},
+		fetchBatch:    10,
+		fetchInterval: 200 * time.Millisecond,
+	}
+	p.codecsByType[JSONCodec{}.ContentType()] = JSONCodec{}
+	p.codecsByType[ProtobufCodec{}.ContentType()] = ProtobufCodec{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithDefaultCodec 设置Publish使用的默认编解码器（默认为JSON）
+func WithDefaultCodec(codec Codec) Option {
+	return func(p *jetStreamPubSub) {
+		p.defaultCodec = codec
+		p.codecsByType[codec.ContentType()] = codec
+	}
+}
+
+func (p *jetStreamPubSub) Publish(ctx context.Context, subject string, v interface{}) error {
+	data, err := p.defaultCodec.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("编码消息失败: %w", err)
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	msg.Header.Set(ContentTypeHeader, p.defaultCodec.ContentType())
+
+	_, err = p.js.PublishMsg(msg, nats.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("发布到 %s 失败: %w", subject, err)
+	}
+	return nil
+}
+
+func (p *jetStreamPubSub) Subscribe(ctx context.Context, subject, durable string, factory func() interface{}, handler Handler) (Subscription, error) {
+	sub, err := p.js.PullSubscribe(subject, durable)
+	if err != nil {
+		return nil, fmt.Errorf("订阅 %s 失败: %w", subject, err)
+	}
+
+	go p.consume(ctx, sub, subject, factory, handler)
+
+	return sub, nil
+}
+
+func (p *jetStreamPubSub) consume(ctx context.Context, sub *nats.Subscription, subject string, factory func() interface{}, handler Handler) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		msgs, err := sub.Fetch(p.fetchBatch, nats.Context(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			time.Sleep(p.fetchInterval)
+			continue
+		}
+
+		for _, msg := range msgs {
+			p.dispatch(msg, subject, factory, handler)
+		}
+	}
+}
+
+func (p *jetStreamPubSub) dispatch(msg *nats.Msg, subject string, factory func() interface{}, handler Handler) {
+	codec := p.defaultCodec
+	if ct := msg.Header.Get(ContentTypeHeader); ct != "" {
+		if c, ok := p.codecsByType[ct]; ok {
+			codec = c
+		}
+	}
+
+	v := factory()
+	if err := codec.Unmarshal(msg.Data, v); err != nil {
+		msg.Nak()
+		return
+	}
+
+	if err := handler(subject, v); err != nil {
+		msg.Nak()
+		return
+	}
+
+	msg.Ack()
+}
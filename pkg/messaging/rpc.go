@@ -0,0 +1,54 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/finovatex/finovatex/pkg/natsrpc"
+)
+
+// Replyer、Client.Call 等类型化请求/应答的实际实现在 pkg/natsrpc；这里
+// 只是把它们按本包的命名习惯重新导出，避免两套RPC实现并存。本包目前
+// 不单独做编解码器选择或panic恢复——这些都由pkg/natsrpc负责（JSON编码、
+// invoke()里的panic恢复），这一层只是薄包装
+type Replyer = natsrpc.Replyer
+
+// RPCHandler 是RPC请求/应答的处理函数类型；命名为RPCHandler而不是
+// Handler，是为了不和本包pubsub.go里已有的发布/订阅Handler撞名
+type RPCHandler = natsrpc.Handler
+
+// Timeout、Exported 等选项原样透出自 pkg/natsrpc
+var (
+	Timeout  = natsrpc.Timeout
+	Exported = natsrpc.Exported
+)
+
+// NewReplyer 在给定主题上注册一个类型化的请求/应答处理器
+func NewReplyer(conn *nats.Conn, subject string, factory func() interface{}, handler RPCHandler, opts ...natsrpc.Option) (*Replyer, error) {
+	return natsrpc.NewReplyer(conn, subject, factory, handler, opts...)
+}
+
+// RPCClient 对 natsrpc.Client 做了一层泛型封装，调用方不必预先声明响应
+// 变量，只需指定类型参数即可拿到解码后的结果
+type RPCClient struct {
+	inner *natsrpc.Client
+}
+
+// NewRPCClient 创建一个RPC客户端
+func NewRPCClient(conn *nats.Conn) *RPCClient {
+	return &RPCClient{inner: natsrpc.NewClient(conn)}
+}
+
+// Call 把req发往subject并把应答解码为Resp类型返回
+func Call[Resp any](ctx context.Context, c *RPCClient, subject string, req interface{}) (Resp, error) {
+	var resp Resp
+	err := c.inner.Call(ctx, subject, req, &resp)
+	return resp, err
+}
+
+// ExportedSubjects 返回当前进程内所有标记为 Exported 的RPC主题，供
+// health.Manager构造RPC健康检查器时使用
+func ExportedSubjects() []string {
+	return natsrpc.ExportedSubjects()
+}
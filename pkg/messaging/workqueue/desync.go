@@ -0,0 +1,103 @@
+package workqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// DesyncDetector 周期性地查询流信息，检查每个副本是否为Current且落后
+// leader的消息数不超过maxLag；流的LastSeq/Messages只作为元数据记录，
+// 不在各副本之间逐一比对；用于在集群复制滞后或分裂时尽早发现，而不是等到消费者读到脏数据
+type DesyncDetector struct {
+	js         nats.JetStreamContext
+	streamName string
+	interval   time.Duration
+	maxLag     uint64
+
+	mu       sync.Mutex
+	ok       bool
+	message  string
+	metadata map[string]interface{}
+}
+
+// NewDesyncDetector 创建一个检测器；maxLag是允许副本落后leader的消息数，
+// 超过则判定为不同步
+func NewDesyncDetector(js nats.JetStreamContext, streamName string, interval time.Duration, maxLag uint64) *DesyncDetector {
+	return &DesyncDetector{
+		js:         js,
+		streamName: streamName,
+		interval:   interval,
+		maxLag:     maxLag,
+		ok:         true,
+		message:    "尚未执行过检测",
+	}
+}
+
+// Start 启动后台轮询，直到ctx被取消
+func (d *DesyncDetector) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	go func() {
+		defer ticker.Stop()
+		d.check()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.check()
+			}
+		}
+	}()
+}
+
+func (d *DesyncDetector) check() {
+	info, err := d.js.StreamInfo(d.streamName)
+	if err != nil {
+		d.record(false, fmt.Sprintf("查询流信息失败: %v", err), nil)
+		return
+	}
+
+	metadata := map[string]interface{}{
+		"messages": info.State.Msgs,
+		"last_seq": info.State.LastSeq,
+	}
+
+	if info.Cluster == nil || len(info.Cluster.Replicas) == 0 {
+		d.record(true, "单节点流，无副本可对比", metadata)
+		return
+	}
+
+	var laggingPeers []string
+	for _, peer := range info.Cluster.Replicas {
+		if !peer.Current || peer.Lag > d.maxLag {
+			laggingPeers = append(laggingPeers, peer.Name)
+		}
+	}
+	metadata["lagging_peers"] = laggingPeers
+
+	if len(laggingPeers) > 0 {
+		d.record(false, fmt.Sprintf("流 %s 存在%d个滞后/不同步副本: %v", d.streamName, len(laggingPeers), laggingPeers), metadata)
+		return
+	}
+
+	d.record(true, fmt.Sprintf("流 %s 的所有副本均已同步", d.streamName), metadata)
+}
+
+func (d *DesyncDetector) record(ok bool, message string, metadata map[string]interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ok = ok
+	d.message = message
+	d.metadata = metadata
+}
+
+// Status 返回检测器最近一次检查的结果，供health.Checker实现读取
+func (d *DesyncDetector) Status() (ok bool, message string, metadata map[string]interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.ok, d.message, d.metadata
+}
@@ -0,0 +1,129 @@
+package workqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// FailureReasonHeader 是消息被转投到DLQ时，携带失败原因的消息头
+const FailureReasonHeader = "Workqueue-Failure-Reason"
+
+// WorkerConfig 描述一个Worker的拉取与重试参数
+type WorkerConfig struct {
+	Stream        string
+	Durable       string
+	Subject       string
+	MaxAckPending int
+	MaxDeliver    int
+	BackoffBase   time.Duration
+	FetchBatch    int
+	FetchTimeout  time.Duration
+}
+
+// Worker 从工作队列流拉取消息并分发给handler；失败时按指数退避Nak重试，
+// 重试次数耗尽后把消息转投到companion的DLQ流
+type Worker struct {
+	js  nats.JetStreamContext
+	cfg WorkerConfig
+	sub *nats.Subscription
+}
+
+// NewWorker 为给定流建立一个拉取式消费者；Durable为空时使用"<Stream>-worker"
+func NewWorker(js nats.JetStreamContext, cfg WorkerConfig) (*Worker, error) {
+	if cfg.Durable == "" {
+		cfg.Durable = cfg.Stream + "-worker"
+	}
+	if cfg.MaxDeliver <= 0 {
+		cfg.MaxDeliver = 5
+	}
+	if cfg.BackoffBase <= 0 {
+		cfg.BackoffBase = time.Second
+	}
+	if cfg.FetchBatch <= 0 {
+		cfg.FetchBatch = 10
+	}
+	if cfg.FetchTimeout <= 0 {
+		cfg.FetchTimeout = 5 * time.Second
+	}
+
+	subOpts := []nats.SubOpt{nats.BindStream(cfg.Stream), nats.ManualAck(), nats.MaxDeliver(cfg.MaxDeliver)}
+	if cfg.MaxAckPending > 0 {
+		subOpts = append(subOpts, nats.MaxAckPending(cfg.MaxAckPending))
+	}
+
+	sub, err := js.PullSubscribe(cfg.Subject, cfg.Durable, subOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("建立工作队列消费者失败 (stream=%s): %w", cfg.Stream, err)
+	}
+
+	return &Worker{js: js, cfg: cfg, sub: sub}, nil
+}
+
+// Run 循环拉取消息并交给handler处理，直到ctx被取消；handler返回错误时
+// 按backoffDelay(重试次数)延迟Nak，重试次数达到MaxDeliver后转投DLQ
+func (w *Worker) Run(ctx context.Context, handler func([]byte) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		msgs, err := w.sub.Fetch(w.cfg.FetchBatch, nats.MaxWait(w.cfg.FetchTimeout))
+		if err != nil {
+			if err == nats.ErrTimeout || err == context.DeadlineExceeded {
+				continue
+			}
+			return fmt.Errorf("拉取工作队列消息失败: %w", err)
+		}
+
+		for _, msg := range msgs {
+			w.handle(msg, handler)
+		}
+	}
+}
+
+func (w *Worker) handle(msg *nats.Msg, handler func([]byte) error) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		msg.Nak()
+		return
+	}
+
+	if handlerErr := handler(msg.Data); handlerErr != nil {
+		if int(meta.NumDelivered) >= w.cfg.MaxDeliver {
+			w.routeToDLQ(msg, handlerErr)
+			msg.Ack()
+			return
+		}
+		msg.NakWithDelay(backoffDelay(int(meta.NumDelivered), w.cfg.BackoffBase))
+		return
+	}
+
+	msg.Ack()
+}
+
+func (w *Worker) routeToDLQ(msg *nats.Msg, reason error) {
+	dlqMsg := nats.NewMsg(dlqSubject(w.cfg.Stream))
+	dlqMsg.Data = msg.Data
+	dlqMsg.Header.Set(FailureReasonHeader, reason.Error())
+	if _, err := w.js.PublishMsg(dlqMsg); err != nil {
+		fmt.Printf("转投死信队列失败 (stream=%s): %v\n", w.cfg.Stream, err)
+	}
+}
+
+// backoffDelay 按2的幂次放大base，上限为base的32倍，避免无限增长
+func backoffDelay(attempt int, base time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	const maxMultiplier = 32
+	multiplier := 1 << (attempt - 1)
+	if multiplier > maxMultiplier {
+		multiplier = maxMultiplier
+	}
+	return base * time.Duration(multiplier)
+}
@@ -0,0 +1,89 @@
+// Package workqueue 在JetStream的WorkQueuePolicy流之上封装了一套
+// 带DLQ（死信队列）和副本失步检测的任务队列：生产者通过Enqueue投递任务，
+// 流满后以DiscardNew策略拒绝新消息而不是淘汰旧消息，消费者通过Worker
+// 拉取并在失败重试用尽后把消息转投到companion的DLQ流
+package workqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ErrMaxMessages 表示队列已达到MaxMsgs上限，DiscardNew策略拒绝了新消息
+var ErrMaxMessages = errors.New("workqueue: 队列已达到最大消息数，消息被拒绝")
+
+// ErrMaxBytes 表示队列已达到MaxBytes上限，DiscardNew策略拒绝了新消息
+var ErrMaxBytes = errors.New("workqueue: 队列已达到最大字节数，消息被拒绝")
+
+// DLQSuffix 是死信队列流名的后缀约定，例如 ORDERS 的死信流叫 ORDERS_DLQ
+const DLQSuffix = "_DLQ"
+
+// StreamConfig 描述一个工作队列流的供给参数
+type StreamConfig struct {
+	Name     string
+	Subjects []string
+	MaxMsgs  int64
+	MaxBytes int64
+}
+
+// DLQName 返回该工作队列对应的死信流名
+func (c StreamConfig) DLQName() string {
+	return c.Name + DLQSuffix
+}
+
+// Provision 幂等地创建（或在已存在时保持不变）工作队列流及其companion死信流；
+// 工作队列流使用WorkQueuePolicy+DiscardNew，死信流使用默认的LimitsPolicy
+// 以便保留失败记录供排查
+func Provision(js nats.JetStreamContext, cfg StreamConfig) error {
+	_, err := js.AddStream(&nats.StreamConfig{
+		Name:      cfg.Name,
+		Subjects:  cfg.Subjects,
+		Retention: nats.WorkQueuePolicy,
+		Discard:   nats.DiscardNew,
+		MaxMsgs:   cfg.MaxMsgs,
+		MaxBytes:  cfg.MaxBytes,
+	})
+	if err != nil && !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		return fmt.Errorf("创建工作队列流 %s 失败: %w", cfg.Name, err)
+	}
+
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     cfg.DLQName(),
+		Subjects: []string{dlqSubject(cfg.Name)},
+	})
+	if err != nil && !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		return fmt.Errorf("创建死信流 %s 失败: %w", cfg.DLQName(), err)
+	}
+
+	return nil
+}
+
+func dlqSubject(streamName string) string {
+	return "workqueue.dlq." + streamName
+}
+
+// Enqueue 把payload发布到subject；当流已满时返回ErrMaxMessages或
+// ErrMaxBytes，便于调用方与普通的传输层错误区分处理
+func Enqueue(ctx context.Context, js nats.JetStreamContext, subject string, payload []byte) error {
+	_, err := js.Publish(subject, payload, nats.Context(ctx))
+	if err == nil {
+		return nil
+	}
+	return classifyPublishError(err)
+}
+
+func classifyPublishError(err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "maximum messages"):
+		return ErrMaxMessages
+	case strings.Contains(msg, "maximum bytes"):
+		return ErrMaxBytes
+	default:
+		return fmt.Errorf("投递到工作队列失败: %w", err)
+	}
+}
@@ -0,0 +1,48 @@
+package workqueue
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClassifyPublishError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"max messages", errors.New("nats: maximum messages exceeded"), ErrMaxMessages},
+		{"max bytes", errors.New("nats: maximum bytes exceeded"), ErrMaxBytes},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := classifyPublishError(c.err)
+			if !errors.Is(got, c.want) {
+				t.Fatalf("期望 %v，得到 %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestClassifyPublishErrorWrapsUnknown(t *testing.T) {
+	original := errors.New("连接已断开")
+	got := classifyPublishError(original)
+	if !errors.Is(got, original) {
+		t.Fatalf("期望包装原始错误，得到 %v", got)
+	}
+}
+
+func TestBackoffDelayGrowsExponentiallyAndCaps(t *testing.T) {
+	base := time.Second
+	if got := backoffDelay(1, base); got != base {
+		t.Fatalf("第一次重试应等于base，得到 %v", got)
+	}
+	if got := backoffDelay(2, base); got != 2*base {
+		t.Fatalf("第二次重试应为2倍base，得到 %v", got)
+	}
+	if got := backoffDelay(10, base); got != 32*base {
+		t.Fatalf("重试次数超过上限后应封顶在32倍base，得到 %v", got)
+	}
+}
@@ -0,0 +1,56 @@
+package natsrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Client 对一个 nats.Conn 发起类型化的请求/应答调用
+type Client struct {
+	conn *nats.Conn
+}
+
+// NewClient 创建一个RPC客户端
+func NewClient(conn *nats.Conn) *Client {
+	return &Client{conn: conn}
+}
+
+// Call 把req编码后发往subject，阻塞直到收到应答、ctx超时或被取消，并把应答
+// 解码进resp；若应答携带 ErrorHeader，则返回该错误而不解码resp
+func (c *Client) Call(ctx context.Context, subject string, req interface{}, resp interface{}) error {
+	data, err := marshalJSON(req)
+	if err != nil {
+		return fmt.Errorf("编码请求失败: %w", err)
+	}
+
+	msg, err := c.conn.RequestWithContext(ctx, subject, data)
+	if err != nil {
+		return fmt.Errorf("调用 %s 失败: %w", subject, err)
+	}
+
+	if errMsg := msg.Header.Get(ErrorHeader); errMsg != "" {
+		return fmt.Errorf("%s 返回错误: %s", subject, errMsg)
+	}
+
+	if resp == nil {
+		return nil
+	}
+
+	if err := unmarshalJSON(msg.Data, resp); err != nil {
+		return fmt.Errorf("解码应答失败: %w", err)
+	}
+
+	return nil
+}
+
+// Ping 向subject发一次空请求，只确认在ctx超时内收到了任意应答（包括错误
+// 应答），不关心应答内容；供健康检查探测某个RPC端点是否存活
+func (c *Client) Ping(ctx context.Context, subject string) error {
+	_, err := c.conn.RequestWithContext(ctx, subject, []byte("{}"))
+	if err != nil {
+		return fmt.Errorf("ping %s 失败: %w", subject, err)
+	}
+	return nil
+}
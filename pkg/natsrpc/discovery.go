@@ -0,0 +1,52 @@
+package natsrpc
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+// discoveryListeners 记录每个连接是否已经注册过发现响应处理器，避免
+// 同一连接上重复订阅 DiscoverySubject
+var (
+	discoveryMu        sync.Mutex
+	discoveryListeners = map[*nats.Conn]bool{}
+)
+
+// registerDiscovery 把subject标记为已导出，并确保该连接上挂了一个响应
+// DiscoverySubject查询的订阅者
+func registerDiscovery(conn *nats.Conn, subject string) {
+	discoveryMu.Lock()
+	defer discoveryMu.Unlock()
+
+	if discoveryListeners[conn] {
+		return
+	}
+	discoveryListeners[conn] = true
+
+	conn.Subscribe(DiscoverySubject, func(msg *nats.Msg) {
+		if msg.Reply == "" {
+			return
+		}
+		data, err := json.Marshal(ExportedSubjects())
+		if err != nil {
+			return
+		}
+		msg.Respond(data)
+	})
+}
+
+// ExportedSubjects 返回当前进程内所有以 Exported() 选项注册的RPC主题
+func ExportedSubjects() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	subjects := make([]string, 0, len(registry))
+	for subject, r := range registry {
+		if r.cfg.exported {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects
+}
@@ -0,0 +1,29 @@
+package natsrpc
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestExportedSubjectsOnlyListsExportedReplyers(t *testing.T) {
+	registryMu.Lock()
+	registry = map[string]*Replyer{
+		"finovatex.rpc.risk.check":     {subject: "finovatex.rpc.risk.check", cfg: replyerConfig{exported: true}},
+		"finovatex.rpc.order.validate": {subject: "finovatex.rpc.order.validate", cfg: replyerConfig{exported: false}},
+	}
+	registryMu.Unlock()
+	t.Cleanup(func() {
+		registryMu.Lock()
+		registry = map[string]*Replyer{}
+		registryMu.Unlock()
+	})
+
+	got := ExportedSubjects()
+	sort.Strings(got)
+
+	want := []string{"finovatex.rpc.risk.check"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("期望导出主题为%v，得到%v", want, got)
+	}
+}
@@ -0,0 +1,21 @@
+package natsrpc
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// LoggingMiddleware 在RPC处理出错时记录subject、耗时与错误；成功的调用不记录
+func LoggingMiddleware(subject string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+			if err != nil {
+				log.Printf("RPC %s 处理失败 (%s): %v", subject, time.Since(start), err)
+			}
+			return resp, err
+		}
+	}
+}
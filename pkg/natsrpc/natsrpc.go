@@ -0,0 +1,71 @@
+// Package natsrpc 在 nats.Conn 之上提供带超时、类型化处理器的请求/应答RPC，
+// 取代各服务手写 Subscribe+Publish 应答对的做法
+package natsrpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// DiscoverySubject 是可被跨服务发现的已导出RPC主题列表所在的主题
+const DiscoverySubject = "finovatex.rpc.discovery"
+
+// ErrorHeader 是应答消息中携带错误信息的NATS消息头
+const ErrorHeader = "Natsrpc-Error"
+
+// DefaultTimeout 是未显式设置Timeout选项时Replyer的服务端处理超时；
+// Client.Call不使用它，调用的截止时间只由传入的ctx决定
+const DefaultTimeout = 5 * time.Second
+
+// Handler 处理一次已解码的请求，返回的resp会被编码后写回reply subject
+type Handler func(ctx context.Context, req interface{}) (resp interface{}, err error)
+
+// Middleware 包装一个Handler，用于注入日志、追踪、鉴权等横切逻辑
+type Middleware func(Handler) Handler
+
+// Option 配置 Replyer 的注册行为
+type Option func(*replyerConfig)
+
+type replyerConfig struct {
+	timeout    time.Duration
+	exported   bool
+	middleware []Middleware
+}
+
+// Timeout 设置该RPC处理的服务端超时；超时后ctx被取消，Handler应尽快返回
+func Timeout(d time.Duration) Option {
+	return func(c *replyerConfig) {
+		c.timeout = d
+	}
+}
+
+// Exported 标记该RPC主题可以被跨服务发现（通过 DiscoverySubject 查询到）
+func Exported() Option {
+	return func(c *replyerConfig) {
+		c.exported = true
+	}
+}
+
+// WithMiddleware 给该Replyer追加中间件，按给定顺序从外到内包裹Handler
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *replyerConfig) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+func newReplyerConfig(opts []Option) replyerConfig {
+	cfg := replyerConfig{timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func unmarshalJSON(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,115 @@
+package natsrpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Replyer 把一个NATS主题绑定到一个类型化的请求处理器：请求按factory构造
+// 的结构体解码，处理结果按JSON编码写回reply subject
+type Replyer struct {
+	conn    *nats.Conn
+	subject string
+	factory func() interface{}
+	handler Handler
+	cfg     replyerConfig
+	sub     *nats.Subscription
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*Replyer{}
+)
+
+// NewReplyer 在给定主题上注册一个类型化的请求/应答处理器，factory为每次
+// 请求构造一个新的请求结构体实例供解码使用
+func NewReplyer(conn *nats.Conn, subject string, factory func() interface{}, handler Handler, opts ...Option) (*Replyer, error) {
+	cfg := newReplyerConfig(opts)
+
+	wrapped := handler
+	for i := len(cfg.middleware) - 1; i >= 0; i-- {
+		wrapped = cfg.middleware[i](wrapped)
+	}
+
+	r := &Replyer{
+		conn:    conn,
+		subject: subject,
+		factory: factory,
+		handler: wrapped,
+		cfg:     cfg,
+	}
+
+	sub, err := conn.Subscribe(subject, r.onRequest)
+	if err != nil {
+		return nil, fmt.Errorf("订阅RPC主题 %s 失败: %w", subject, err)
+	}
+	r.sub = sub
+
+	registryMu.Lock()
+	registry[subject] = r
+	registryMu.Unlock()
+
+	if cfg.exported {
+		registerDiscovery(conn, subject)
+	}
+
+	return r, nil
+}
+
+// Close 取消订阅并把该主题从发现注册表中移除
+func (r *Replyer) Close() error {
+	registryMu.Lock()
+	delete(registry, r.subject)
+	registryMu.Unlock()
+
+	return r.sub.Unsubscribe()
+}
+
+func (r *Replyer) onRequest(msg *nats.Msg) {
+	if msg.Reply == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.timeout)
+	defer cancel()
+
+	resp, err := r.invoke(ctx, msg.Data)
+	if err != nil {
+		r.replyError(msg, err)
+		return
+	}
+
+	data, err := marshalJSON(resp)
+	if err != nil {
+		r.replyError(msg, fmt.Errorf("编码应答失败: %w", err))
+		return
+	}
+
+	msg.Respond(data)
+}
+
+// invoke 解码请求并调用处理器；recover()把处理器panic转换为错误应答，
+// 避免一次调用拖垮整个RPC订阅goroutine
+func (r *Replyer) invoke(ctx context.Context, data []byte) (resp interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("RPC处理器panic: %v", p)
+		}
+	}()
+
+	req := r.factory()
+	if unmarshalErr := unmarshalJSON(data, req); unmarshalErr != nil {
+		return nil, fmt.Errorf("解码请求失败: %w", unmarshalErr)
+	}
+
+	return r.handler(ctx, req)
+}
+
+func (r *Replyer) replyError(msg *nats.Msg, err error) {
+	reply := nats.NewMsg(msg.Reply)
+	reply.Header.Set(ErrorHeader, err.Error())
+	r.conn.PublishMsg(reply)
+}
@@ -0,0 +1,109 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Divergence 描述一个向量运行结果与期望之间的首个不一致
+type Divergence struct {
+	VectorName string
+	Reason     string
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("向量 %s 一致性校验失败: %s", d.VectorName, d.Reason)
+}
+
+// Run 针对单个向量：provision临时流、回放输入事件、拉取各期望主题上的
+// 消息并与向量比对，返回首个发现的分歧（nil表示通过）
+func Run(js nats.JetStreamContext, v Vector) (*Divergence, error) {
+	streamName := v.Stream
+	if streamName == "" {
+		streamName = "CONFORMANCE_" + sanitize(v.Name)
+	}
+
+	if _, err := js.AddStream(&nats.StreamConfig{
+		Name:     streamName,
+		Subjects: v.Subjects,
+		Storage:  nats.MemoryStorage,
+	}); err != nil {
+		return nil, fmt.Errorf("provision流 %s 失败: %w", streamName, err)
+	}
+	defer js.DeleteStream(streamName)
+
+	inputData, err := json.Marshal(v.InputPayload)
+	if err != nil {
+		return nil, fmt.Errorf("序列化输入事件失败: %w", err)
+	}
+	if _, err := js.Publish(v.InputSubject, inputData); err != nil {
+		return nil, fmt.Errorf("发布输入事件失败: %w", err)
+	}
+
+	for _, expected := range v.ExpectedMessages {
+		consumerName := "conformance-" + sanitize(expected.Subject)
+		sub, err := js.PullSubscribe(expected.Subject, consumerName, nats.BindStream(streamName))
+		if err != nil {
+			return nil, fmt.Errorf("订阅 %s 失败: %w", expected.Subject, err)
+		}
+
+		msgs, err := sub.Fetch(1, nats.MaxWait(5*time.Second))
+		sub.Unsubscribe()
+		if err != nil || len(msgs) == 0 {
+			return &Divergence{VectorName: v.Name, Reason: fmt.Sprintf("主题 %s 未收到预期消息", expected.Subject)}, nil
+		}
+
+		var observed map[string]interface{}
+		if err := json.Unmarshal(msgs[0].Data, &observed); err != nil {
+			return nil, fmt.Errorf("解析观测消息失败: %w", err)
+		}
+
+		if diff := diffPayload(expected.Payload, observed, expected.ToleranceFields); diff != "" {
+			return &Divergence{VectorName: v.Name, Reason: diff}, nil
+		}
+
+		msgs[0].Ack()
+	}
+
+	return nil, nil
+}
+
+// diffPayload 按expected中的键逐一比对observed，ToleranceFields中列出的键
+// 只校验存在性、不比较具体值（典型用法是时间戳）
+func diffPayload(expected, observed map[string]interface{}, toleranceFields []string) string {
+	tolerated := make(map[string]bool, len(toleranceFields))
+	for _, f := range toleranceFields {
+		tolerated[f] = true
+	}
+
+	for key, expectedVal := range expected {
+		observedVal, ok := observed[key]
+		if !ok {
+			return fmt.Sprintf("字段 %s 缺失", key)
+		}
+		if tolerated[key] {
+			continue
+		}
+		if fmt.Sprintf("%v", expectedVal) != fmt.Sprintf("%v", observedVal) {
+			return fmt.Sprintf("字段 %s 不一致: 期望=%v 实际=%v", key, expectedVal, observedVal)
+		}
+	}
+
+	return ""
+}
+
+func sanitize(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			out = append(out, r)
+		default:
+			out = append(out, '_')
+		}
+	}
+	return string(out)
+}
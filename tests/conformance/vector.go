@@ -0,0 +1,89 @@
+// Package conformance 提供由外部测试向量仓库驱动的消息管道一致性测试
+// 运行器：每个向量描述一个输入行情事件、预期产生的下游JetStream消息序列
+// 以及预期的消费者状态
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExpectedMessage 描述向量期望在某个主题上观察到的一条消息
+type ExpectedMessage struct {
+	Subject string                 `json:"subject" yaml:"subject"`
+	Payload map[string]interface{} `json:"payload" yaml:"payload"`
+	// ToleranceFields 列出允许在diff时忽略具体值、只校验字段存在的键
+	// （典型用法是时间戳字段，运行时间与录制时间必然不同）
+	ToleranceFields []string `json:"tolerance_fields" yaml:"tolerance_fields"`
+}
+
+// ExpectedConsumerState 描述向量期望的消费者状态
+type ExpectedConsumerState struct {
+	Pending     int `json:"pending" yaml:"pending"`
+	Acked       int `json:"acked" yaml:"acked"`
+	Redelivered int `json:"redelivered" yaml:"redelivered"`
+}
+
+// Vector 是一个完整的一致性测试向量
+type Vector struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Stream/Subjects 描述运行向量时临时provision的JetStream流
+	Stream  string   `json:"stream" yaml:"stream"`
+	Subjects []string `json:"subjects" yaml:"subjects"`
+
+	// InputSubject/InputPayload 是驱动管道的原始输入事件
+	InputSubject string                 `json:"input_subject" yaml:"input_subject"`
+	InputPayload map[string]interface{} `json:"input_payload" yaml:"input_payload"`
+
+	ExpectedMessages      []ExpectedMessage     `json:"expected_messages" yaml:"expected_messages"`
+	ExpectedConsumerState ExpectedConsumerState `json:"expected_consumer_state" yaml:"expected_consumer_state"`
+}
+
+// LoadVectors 从目录中加载所有 .json/.yaml/.yml 测试向量文件
+func LoadVectors(dir string) ([]Vector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("读取向量目录失败: %w", err)
+	}
+
+	var vectors []Vector
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取向量文件 %s 失败: %w", path, err)
+		}
+
+		var v Vector
+		if ext == ".json" {
+			err = json.Unmarshal(data, &v)
+		} else {
+			err = yaml.Unmarshal(data, &v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析向量文件 %s 失败: %w", path, err)
+		}
+		if v.Name == "" {
+			v.Name = entry.Name()
+		}
+
+		vectors = append(vectors, v)
+	}
+
+	return vectors, nil
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/finovatex/finovatex/pkg/messaging"
+	"github.com/finovatex/finovatex/pkg/messaging/pb"
 )
 
 // TestMessage 测试消息结构
@@ -101,77 +106,70 @@ func TestJetStreamStreams(t *testing.T) {
 	}
 }
 
-// TestMarketDataPublishSubscribe 测试市场数据发布订阅
+// TestMarketDataPublishSubscribe 测试市场数据发布订阅，分别用JSON与Protobuf
+// 编解码器覆盖同一条发布/订阅路径，保证下游的Python/Rust消费者都能互通
 func TestMarketDataPublishSubscribe(t *testing.T) {
 	t.Log("Testing market data publish/subscribe...")
-	
-	conn := setupNATSConnection(t)
-	defer conn.Close()
-	
-	js := setupJetStream(t, conn)
-	
-	// 创建消费者
-	consumerName := fmt.Sprintf("test-consumer-%d", time.Now().Unix())
-	consumer, err := js.PullSubscribe("finovatex.market.ticker.BTCUSDT", consumerName, nats.BindStream("MARKET_DATA"))
-	require.NoError(t, err, "Failed to create consumer")
-	defer consumer.Unsubscribe()
-	
-	// 发布测试消息
-	testMessages := make([]TestMessage, messageCount)
-	for i := 0; i < messageCount; i++ {
-		testMessages[i] = TestMessage{
-			ID:        fmt.Sprintf("test-msg-%d", i),
-			Type:      "price_update",
-			Symbol:    "BTCUSDT",
-			Price:     45000.0 + float64(i)*10,
-			Volume:    1.5 + float64(i)*0.1,
-			Timestamp: time.Now(),
-		}
-		
-		msgData, err := json.Marshal(testMessages[i])
-		require.NoError(t, err)
-		
-		_, err = js.Publish("finovatex.market.ticker.BTCUSDT", msgData)
-		require.NoError(t, err, "Failed to publish message %d", i)
+
+	codecs := map[string]messaging.Codec{
+		"json":     messaging.JSONCodec{},
+		"protobuf": messaging.ProtobufCodec{},
 	}
-	
-	t.Logf("Published %d test messages", messageCount)
-	
-	// 订阅并验证消息
-	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
-	defer cancel()
-	
-	receivedCount := 0
-	for receivedCount < messageCount {
-		msgs, err := consumer.Fetch(messageCount, nats.Context(ctx))
-		if err != nil {
-			if ctx.Err() != nil {
-				t.Fatalf("Timeout waiting for messages. Received %d/%d", receivedCount, messageCount)
+
+	for name, codec := range codecs {
+		codec := codec
+		t.Run(name, func(t *testing.T) {
+			conn := setupNATSConnection(t)
+			defer conn.Close()
+
+			js := setupJetStream(t, conn)
+			ps := messaging.NewPubSub(js, messaging.WithDefaultCodec(codec))
+
+			consumerName := fmt.Sprintf("test-consumer-%s-%d", name, time.Now().Unix())
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			received := make(chan *pb.MarketTick, messageCount)
+			sub, err := ps.Subscribe(ctx, "finovatex.market.ticker.BTCUSDT", consumerName,
+				func() interface{} { return &pb.MarketTick{} },
+				func(subject string, v interface{}) error {
+					received <- v.(*pb.MarketTick)
+					return nil
+				})
+			require.NoError(t, err, "Failed to subscribe")
+			defer sub.Unsubscribe()
+
+			for i := 0; i < messageCount; i++ {
+				tick := &pb.MarketTick{
+					Symbol:            "BTCUSDT",
+					Type:              "price_update",
+					Price:             45000.0 + float64(i)*10,
+					Volume:            1.5 + float64(i)*0.1,
+					TimestampUnixNano: time.Now().UnixNano(),
+				}
+				err := ps.Publish(ctx, "finovatex.market.ticker.BTCUSDT", tick)
+				require.NoError(t, err, "Failed to publish message %d", i)
 			}
-			continue
-		}
-		
-		for _, msg := range msgs {
-			var receivedMsg TestMessage
-			err := json.Unmarshal(msg.Data, &receivedMsg)
-			assert.NoError(t, err, "Failed to unmarshal message")
-			
-			// 验证消息内容
-			assert.Equal(t, "BTCUSDT", receivedMsg.Symbol)
-			assert.Equal(t, "price_update", receivedMsg.Type)
-			assert.True(t, receivedMsg.Price >= 45000.0)
-			
-			msg.Ack()
-			receivedCount++
-			
-			if receivedCount >= messageCount {
-				break
+
+			t.Logf("Published %d test messages via %s codec", messageCount, name)
+
+			receivedCount := 0
+			for receivedCount < messageCount {
+				select {
+				case tick := <-received:
+					assert.Equal(t, "BTCUSDT", tick.Symbol)
+					assert.Equal(t, "price_update", tick.Type)
+					assert.True(t, tick.Price >= 45000.0)
+					receivedCount++
+				case <-ctx.Done():
+					t.Fatalf("Timeout waiting for messages. Received %d/%d", receivedCount, messageCount)
+				}
 			}
-		}
+
+			assert.Equal(t, messageCount, receivedCount, "Should receive all published messages")
+			t.Logf("✓ Successfully received %d messages via %s codec", receivedCount, name)
+		})
 	}
-	
-	assert.Equal(t, messageCount, receivedCount, "Should receive all published messages")
-	t.Logf("✓ Successfully received %d messages", receivedCount)
 }
 
 // TestTradingSignalsFlow 测试交易信号流
@@ -421,6 +419,125 @@ func TestErrorHandling(t *testing.T) {
 	t.Log("✓ Error handling test passed")
 }
 
+// TestQueueGroupLoadBalancing 验证同一个DeliverGroup下的多个worker会
+// 负载均衡地瓜分消息：所有worker收到的消息并集等于发布的消息集合，
+// 交集为空（同一条消息不会被两个worker同时处理）
+func TestQueueGroupLoadBalancing(t *testing.T) {
+	t.Log("Testing queue group load balancing...")
+
+	conn := setupNATSConnection(t)
+	defer conn.Close()
+
+	js := setupJetStream(t, conn)
+
+	subject := "finovatex.market.trade.BTCUSDT"
+	group := fmt.Sprintf("load-balance-group-%d", time.Now().UnixNano())
+
+	const workerCount = 3
+	const messageCount = 30
+
+	var mu sync.Mutex
+	received := make(map[string]int)
+	var wg sync.WaitGroup
+	wg.Add(messageCount)
+
+	pool, err := messaging.NewWorkerPool(js, subject, group, group, workerCount, messaging.JSONCodec{},
+		func() interface{} { return &TestMessage{} },
+		func(subject string, v interface{}) error {
+			msg := v.(*TestMessage)
+			mu.Lock()
+			received[msg.ID]++
+			mu.Unlock()
+			wg.Done()
+			return nil
+		})
+	require.NoError(t, err, "Failed to create worker pool")
+	defer pool.Stop()
+
+	for i := 0; i < messageCount; i++ {
+		testMsg := TestMessage{
+			ID:        fmt.Sprintf("lb-test-%d-%d", time.Now().UnixNano(), i),
+			Type:      "trade",
+			Symbol:    "BTCUSDT",
+			Price:     50000.0,
+			Volume:    0.1,
+			Timestamp: time.Now(),
+		}
+		msgData, err := json.Marshal(testMsg)
+		require.NoError(t, err)
+		_, err = js.Publish(subject, msgData)
+		require.NoError(t, err, "Failed to publish message %d", i)
+	}
+
+	waitDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(waitDone)
+	}()
+
+	select {
+	case <-waitDone:
+	case <-time.After(20 * time.Second):
+		t.Fatal("Timed out waiting for all messages to be processed")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, messageCount, len(received), "every published message should be processed exactly once")
+	for id, count := range received {
+		assert.Equal(t, 1, count, "message %s should not be processed by more than one worker", id)
+	}
+
+	t.Log("✓ Queue group load balancing test passed")
+}
+
+// TestIdempotentPublishDeduplicates 并发两次用相同的去重key发布同一条
+// 消息，验证handler只会被调用一次
+func TestIdempotentPublishDeduplicates(t *testing.T) {
+	t.Log("Testing idempotent publish deduplication...")
+
+	conn := setupNATSConnection(t)
+	defer conn.Close()
+
+	js := setupJetStream(t, conn)
+
+	bucket := fmt.Sprintf("IDEMPOTENCY_%d", time.Now().UnixNano())
+	idem, err := messaging.NewIdempotentPubSub(js, bucket, time.Minute)
+	require.NoError(t, err, "Failed to create idempotent pubsub")
+
+	subject := "finovatex.market.trade.ETHUSDT"
+	key := fmt.Sprintf("idem-test-%d", time.Now().UnixNano())
+
+	var callCount int32
+	sub, err := idem.Subscribe(context.Background(), subject,
+		func(v interface{}) string { return v.(*TestMessage).Symbol },
+		func() interface{} { return &TestMessage{} },
+		func(subject string, v interface{}) error {
+			atomic.AddInt32(&callCount, 1)
+			return nil
+		})
+	require.NoError(t, err, "Failed to subscribe")
+	defer sub.Unsubscribe()
+
+	testMsg := TestMessage{ID: key, Type: "trade", Symbol: "ETHUSDT", Price: 3500.0, Volume: 1.0, Timestamp: time.Now()}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer wg.Done()
+			_ = idem.Publish(context.Background(), subject, key, testMsg)
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(3 * time.Second)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount), "handler should run exactly once for a duplicate key")
+
+	t.Log("✓ Idempotent publish deduplication test passed")
+}
+
 // BenchmarkNATSPublish 发布性能基准测试
 func BenchmarkNATSPublish(b *testing.B) {
 	conn, err := nats.Connect(natsURL)